@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -8,35 +9,79 @@ import (
 
 	"github.com/pxp/hub-tui/internal/app"
 	"github.com/pxp/hub-tui/internal/config"
+	"github.com/pxp/hub-tui/internal/ui/theme"
+	"github.com/pxp/hub-tui/internal/version"
 )
 
 func main() {
-	// Load config (creates empty config if file doesn't exist)
-	cfg, err := config.Load()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
+	demo := flag.Bool("demo", false, "run with sample data instead of connecting to hub-core")
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	configPath := flag.String("config", "", "config file to use instead of the default")
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintln(out, "Usage: hub-tui [flags]")
+		flag.PrintDefaults()
+		fmt.Fprintln(out, "\nEnvironment (takes precedence over the config file, never saved to it):")
+		fmt.Fprintln(out, "  "+config.EnvServerURL+"\thub-core server URL")
+		fmt.Fprintln(out, "  "+config.EnvToken+"\tauth token; skips login while valid")
 	}
+	flag.Parse()
 
-	// Save config to ensure the config file exists
-	if err := cfg.Save(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
-		os.Exit(1)
+	if *showVersion {
+		fmt.Println("hub-tui " + version.String())
+		return
 	}
 
-	// Create the app model
-	model := app.New(cfg)
+	// The default code highlighting theme depends on the background
+	theme.DetectBackground()
 
-	// Create the program
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	var cfg *config.Config
+	var model app.Model
+	if *demo {
+		// Demo mode leaves the real config untouched
+		cfg = config.NewInMemory()
+		model = app.NewDemo(cfg)
+	} else {
+		// Load config (creates empty config if file doesn't exist)
+		var err error
+		if *configPath != "" {
+			cfg, err = config.LoadFrom(*configPath)
+		} else {
+			cfg, err = config.Load()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Save config to ensure the config file exists
+		if err := cfg.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Create the app model
+		model = app.New(cfg)
+	}
+
+	// Render through one writer so notifications can't tear a frame
+	term := app.NewTerminal(os.Stdout)
+	model.SetTerminal(term)
 
-	// Set program reference for streaming (via a startup command)
-	go func() {
-		// Small delay to ensure program is running
-		p.Send(app.SetProgramMsg{Program: p})
-	}()
+	// Create the program
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithOutput(term)}
+	if cfg.MouseEnabled() {
+		// Wheel scrolling; hold Shift for native text selection
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(model, opts...)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	// Save state even if the program was killed or failed
+	if m, ok := final.(app.Model); ok {
+		m.Shutdown()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running app: %v\n", err)
 		os.Exit(1)
 	}
@@ -2,6 +2,9 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,13 +14,37 @@ import (
 	"github.com/pxp/hub-tui/internal/config"
 	"github.com/pxp/hub-tui/internal/ui/chat"
 	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/login"
 	"github.com/pxp/hub-tui/internal/ui/modal"
 	"github.com/pxp/hub-tui/internal/ui/status"
+	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
 const quitHintDuration = 2 * time.Second
 
+// defaultWidth and defaultHeight are the assumed terminal size until
+// Bubble Tea reports the real one.
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// minWidth is the narrowest terminal the layout supports.
+const minWidth = 40
+
+// statusTickInterval is how often the connecting indicator animates.
+const statusTickInterval = 300 * time.Millisecond
+
+// refreshAgeInterval is how often the status bar's cache age is redrawn.
+const refreshAgeInterval = 30 * time.Second
+
+// streamBufferSize is how many streamed messages can queue before the client blocks.
+const streamBufferSize = 64
+
+// routeNotePrefix starts the note shown above a reply to /ask.
+const routeNotePrefix = "→ routed to "
+
 // AppState represents the current application state.
 type AppState int
 
@@ -40,28 +67,81 @@ type Context struct {
 	Target string // Name of target (empty for hub)
 }
 
+// transcriptKey returns the context whose transcript is shown in c.
+// Each assistant has its own transcript; everything else shares the hub's.
+func (c Context) transcriptKey() Context {
+	if c.Type == "assistant" && c.Target != "" {
+		return c
+	}
+	return Context{Type: "hub"}
+}
+
+// contextState is the saved conversation of an inactive context.
+type contextState struct {
+	transcript       chat.Transcript
+	lastPrompt       string
+	lastPromptTarget string
+}
+
 // TaskState tracks running and completed workflow tasks.
 type TaskState struct {
 	Running   []Run
 	Completed []Run
 	Failed    []Run
+
+	// IDs of runs needing attention as of the last poll, nil before the
+	// first one, for spotting runs that newly need attention
+	attention map[string]bool
 }
 
 // Model is the root Bubble Tea model for hub-tui.
 type Model struct {
 	config       *config.Config
-	client       *client.Client
-	program      *tea.Program // Reference for sending messages from goroutines
+	client       client.Interface
 	cache        Cache
-	context      Context   // Current conversation context
-	tasks        TaskState // Workflow task tracking
+	context      Context                  // Current conversation context
+	saved        map[Context]contextState // Conversations of inactive contexts
+	tasks        TaskState                // Workflow task tracking
 	width        int
 	height       int
 	state        AppState
 	quitting     bool
+	shutDown     bool // Shutdown has run
 	ctrlCPressed bool
 	cancelAsk    context.CancelFunc // Cancel function for streaming request
 
+	// Whether the status bar's connecting animation is running
+	statusTicking bool
+
+	// Whether the status bar's cache age is being kept current
+	refreshAgeTicking bool
+
+	// Whether a /refresh is in progress, to report its outcome
+	refreshing bool
+
+	// Whether the client serves canned data instead of talking to hub-core
+	demo bool
+
+	// Program output, for notifications sent outside the renderer
+	term *Terminal
+
+	// Key bindings, from the defaults and the config's overrides
+	keys *keys.Keymap
+
+	// Context saved by the last session, restored once the cache
+	// confirms the assistant still exists
+	restoreContext Context
+
+	// Last prompt sent, for regenerating the reply
+	lastPrompt       string // User input that produced the last hub message
+	lastPromptTarget string // Assistant it was sent to directly ("" for /ask)
+
+	// File attached with /attach, sent with the next message
+	attachment *attachment
+
+	// Last workflow started, for /rerun
+	lastWorkflow string
+
 	// Workflow cancel hint tracking (single active hint)
 	workflowHintRunID  string // Run ID of workflow with active hint
 	workflowHintMsgIdx int    // Message index where hint is displayed
@@ -84,80 +164,172 @@ func New(cfg *config.Config) Model {
 		chat:      chat.New(),
 		statusBar: status.New(),
 		modal:     modal.NewState(),
+		saved:     make(map[Context]contextState),
+	}
+	m.restoreContext = Context{Type: cfg.ContextType, Target: cfg.ContextTarget}
+	km, err := keys.New(cfg.Keybindings)
+	if err != nil {
+		km = keys.Default()
+		m.chat.AddSystemMessage("Invalid keybindings (" + err.Error() + "); using the defaults.")
+	}
+	m.keys = km
+	m.chat.SetKeymap(km)
+	m.modal.SetKeymap(km)
+	m.statusBar.SetQuitKey(km.Label(keys.Quit))
+	m.chat.SetMaxInputLines(cfg.MaxInputLines)
+	m.chat.SetInputCharLimit(cfg.InputCharLimit)
+	m.chat.SetCompact(cfg.CompactChat)
+	if !chat.SetCodeTheme(cfg.CodeTheme) {
+		m.chat.AddSystemMessage("Unknown code theme \"" + cfg.CodeTheme + "\"; using the default.")
 	}
 
 	if needsLogin {
 		m.state = StateLogin
-		m.login = login.New(needsServerURL, cfg.ServerURL)
+		m.login = m.newLogin(needsServerURL, cfg.ServerURL)
 	} else {
 		m.state = StateMain
 		m.client = client.New(cfg.ServerURL)
 		m.client.SetToken(cfg.Token)
 		m.statusBar.SetServerURL(cfg.ServerURL)
+		// Init starts the health check and connecting animation
+		m.statusBar.SetState(status.StateConnecting)
+		m.statusTicking = true
 	}
 
+	// Lay out for a standard terminal until the real size arrives
+	m.setSize(defaultWidth, defaultHeight)
+
 	return m
 }
 
-// SetProgram sets the tea.Program reference for sending messages.
-func (m *Model) SetProgram(p *tea.Program) {
-	m.program = p
+// SetTerminal sets the output the program renders to. Notifications are
+// only sent once it's set.
+func (m *Model) SetTerminal(t *Terminal) {
+	m.term = t
+}
+
+// setSize lays out every component for a terminal of the given size.
+func (m *Model) setSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.login.SetSize(width, height)
+	m.statusBar.SetWidth(width)
+	m.modal.SetWidth(width)
+	// Chat gets height minus status bar
+	m.chat.SetSize(width, height-1)
+	m.modal.SetHeight(m.modalHeight())
+}
+
+// NewDemo creates an app model backed by a mock client with sample data
+// instead of a hub-core server.
+func NewDemo(cfg *config.Config) Model {
+	cfg.ServerURL = client.MockBaseURL
+	cfg.Token = "demo"
+	m := New(cfg)
+	m.demo = true
+	m.client = client.NewMock()
+	m.client.SetToken(cfg.Token)
+	return m
+}
+
+// newLogin creates a login form offering the recent servers.
+func (m *Model) newLogin(needsServerURL bool, serverURL string) login.Model {
+	l := login.New(needsServerURL, serverURL)
+	l.SetRecentServers(m.config.RecentServers)
+	l.SetQuitKey(m.keys.Label(keys.Quit))
+	return l
+}
+
+// newTasksModal creates the tasks modal with the user's display preferences.
+func (m *Model) newTasksModal() *modal.TasksModal {
+	tasks := modal.NewTasksModal(m.client, m.config.ConfirmTimeout())
+	tasks.SetAbsoluteTimes(m.config.AbsoluteTaskTimes)
+	return tasks
+}
+
+// newWorkflowsModal creates the workflows modal with the user's filter
+// preference.
+func (m *Model) newWorkflowsModal() *modal.WorkflowsModal {
+	workflows := modal.NewWorkflowsModal(m.client)
+	workflows.SetEnabledOnly(m.config.EnabledWorkflowsOnly)
+	return workflows
+}
+
+// newClient returns a client for serverURL, or the mock in demo mode.
+func (m *Model) newClient(serverURL string) client.Interface {
+	if m.demo {
+		return m.client
+	}
+	return client.New(serverURL)
 }
 
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
 	if m.state == StateMain {
 		// Verify connection with health check
-		return m.doHealthCheck()
+		return tea.Batch(tea.WindowSize(), m.doHealthCheck(), statusTick(0))
 	}
-	return nil
+	return tea.WindowSize()
 }
 
 // Update handles messages and updates the model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case SetProgramMsg:
-		m.program = msg.Program
-		return m, nil
+	case streamEventMsg:
+		// Handle the streamed message, then wait for the next one
+		model, cmd := m.Update(msg.Msg)
+		return model, tea.Batch(cmd, waitForStream(msg.stream))
 
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.login.SetSize(msg.Width, msg.Height)
-		m.statusBar.SetWidth(msg.Width)
-		m.modal.SetWidth(msg.Width)
-		// Chat gets height minus status bar
-		m.chat.SetSize(msg.Width, msg.Height-1)
+		m.setSize(msg.Width, msg.Height)
 		return m, nil
 
 	case tea.KeyMsg:
 		// Global key handling
-		if IsQuit(msg) {
+		if m.keys.Is(msg, keys.Quit) {
+			streaming := m.chat.IsStreaming()
 			// Cancel any ongoing streaming
 			if m.cancelAsk != nil {
 				m.cancelAsk()
 			}
 			if m.ctrlCPressed {
-				m.quitting = true
-				return m, tea.Quit
+				return m, m.quit()
 			}
 			m.ctrlCPressed = true
 			m.login.SetCtrlCPressed(true)
 			m.statusBar.SetCtrlCPressed(true)
+			// The stream stops here; the partial reply stays visible
+			if streaming {
+				m.statusBar.SetQuitHint("Streaming in progress — " + m.keys.Label(keys.Quit) + " again to quit")
+			} else {
+				m.statusBar.SetQuitHint("")
+			}
 			return m, tea.Tick(quitHintDuration, func(time.Time) tea.Msg {
 				return QuitHintExpiredMsg{}
 			})
 		}
 
-		if IsRedraw(msg) {
+		if m.keys.Is(msg, keys.Redraw) {
 			return m, tea.ClearScreen
 		}
 
-		// Reset Ctrl+C state on any other key
+		// Reset quit state on any other key
 		m.ctrlCPressed = false
 		m.login.SetCtrlCPressed(false)
 		m.statusBar.SetCtrlCPressed(false)
 
+		if m.keys.Is(msg, keys.Attention) && m.state == StateMain {
+			if tasks, ok := m.modal.Active.(*modal.TasksModal); ok {
+				tasks.NextNeedsAttention()
+				return m, nil
+			}
+			if !m.modal.IsOpen() {
+				tasks := m.newTasksModal()
+				tasks.NextNeedsAttention()
+				return m, m.modal.Open(tasks)
+			}
+		}
+
 		// Route to modal if open
 		if m.modal.IsOpen() {
 			handled, cmd := m.modal.Update(msg)
@@ -174,6 +346,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateMain(msg)
 		}
 
+	case tea.MouseMsg:
+		if m.state != StateMain {
+			return m, nil
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMouse(msg, m.modalTop())
+			return m, cmd
+		}
+		var cmd tea.Cmd
+		m.chat, cmd = m.chat.Update(msg)
+		return m, cmd
+
 	case QuitHintExpiredMsg:
 		m.ctrlCPressed = false
 		m.login.SetCtrlCPressed(false)
@@ -194,15 +378,60 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.chat.FinishLastMessage()
 		m.cancelAsk = nil
 		if msg.Error != nil {
-			// Could show error to user here
+			if client.IsAuthError(msg.Error) {
+				return m.handleAuthExpired()
+			}
+			// Cancelled streams were stopped by the user, not failures
+			if !errors.Is(msg.Error, context.Canceled) {
+				m.chat.AppendErrorToLastMessage("Error: " + msg.Error.Error())
+			}
+			return m, nil
+		}
+		return m, m.loadReplyRun(msg.RunID)
+
+	case ReplyRunLoadedMsg:
+		if msg.Error != nil {
+			if client.IsAuthError(msg.Error) {
+				return m.handleAuthExpired()
+			}
+			// The reply itself already arrived; the step summary is extra
+			return m, nil
+		}
+		if msg.Run != nil && msg.Run.Result != nil {
+			m.chat.SetSteps(msg.MsgIdx, replySteps(msg.Run.Result.Steps))
 		}
 		return m, nil
 
+	case chat.ThinkingTickMsg:
+		var cmd tea.Cmd
+		m.chat, cmd = m.chat.Update(msg)
+		return m, cmd
+
+	case StatusTickMsg:
+		// Stop ticking once the connection attempt has finished
+		if m.statusBar.State() != status.StateConnecting {
+			m.statusTicking = false
+			return m, nil
+		}
+		m.statusBar.SetFrame(msg.Frame)
+		return m, statusTick(msg.Frame + 1)
+
+	case RefreshAgeTickMsg:
+		// Returning redraws the age; stop once there's no cache
+		if m.cache.LastUpdate.IsZero() {
+			m.refreshAgeTicking = false
+			return m, nil
+		}
+		return m, refreshAgeTick()
+
 	case RouteMsg:
-		m.context.Type = msg.Type
-		m.context.Target = msg.Target
-		m.statusBar.SetContext(msg.Type, msg.Target)
-		m.chat.SetInContext(msg.Type == "assistant" && msg.Target != "")
+		m.setContext(msg.Type, msg.Target)
+		if msg.Ask {
+			m.chat.SetReplySource(msg.Type)
+		}
+		if msg.Ask && !m.config.HideRouteTrace {
+			m.chat.AddNoteBeforeReply(routeNotePrefix + msg.Type + ": " + msg.Target)
+		}
 		return m, nil
 
 	case AskNeedsInputMsg:
@@ -211,7 +440,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.chat.FinishLastMessage()
 
 		// Open parameter form modal
-		formModal := modal.NewParamFormModal(msg.Target, msg.Schema)
+		formModal := modal.NewParamFormModalWithLast(msg.Target, msg.Schema, m.config.LastParams(msg.Target), m.config.ConfirmTimeout())
 		cmd := m.modal.Open(formModal)
 		return m, cmd
 
@@ -222,7 +451,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.chat.ReplaceLastMessageContent("Done.")
 		}
-		return m, nil
+		return m, m.loadReplyRun(msg.RunID)
 
 	case AskErrorMsg:
 		// Replace placeholder with error message
@@ -234,8 +463,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case modal.ParamFormSubmitMsg:
-		// Close modal and submit structured params
+		// Close modal and submit structured params, remembering them
+		// to pre-fill the form next time
 		m.modal.Close()
+		_ = m.config.SaveParams(msg.Target, msg.Params) // Best effort save
 		return m, m.doAskWithParams(msg.Target, msg.Params)
 
 	case modal.ParamFormCancelMsg:
@@ -247,9 +478,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case CacheRefreshMsg:
 		return m.handleCacheRefresh(msg)
 
+	case ModelCommandMsg:
+		if msg.Error != nil {
+			if client.IsAuthError(msg.Error) {
+				return m.handleAuthExpired()
+			}
+			m.chat.AddSystemMessage("Error: " + msg.Error.Error())
+			return m, nil
+		}
+		if msg.Profile != "" {
+			m.statusBar.SetActiveProfile(msg.Profile)
+		}
+		m.chat.AddSystemMessage(msg.Message)
+		return m, nil
+
 	case AuthExpiredMsg:
 		return m.handleAuthExpired()
 
+	case components.SpinnerTickMsg:
+		// Always forward so modal state can end the tick loop after close
+		_, cmd := m.modal.UpdateMsg(msg)
+		return m, cmd
+
 	case modal.ModulesLoadedMsg:
 		if msg.Error != nil && client.IsAuthError(msg.Error) {
 			return m.handleAuthExpired()
@@ -295,6 +545,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	case modal.IntegrationDefaultSetMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
+	case modal.IntegrationProfileDeletedMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
+	case modal.IntegrationConfigValidatedMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
+	case modal.IntegrationProfileTestedMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
 	case modal.IntegrationTestedMsg:
 		if msg.Error != nil && client.IsAuthError(msg.Error) {
 			return m.handleAuthExpired()
@@ -394,10 +680,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	case modal.LLMProfilesTestedMsg:
+		if msg.Err != nil && client.IsAuthError(msg.Err) {
+			return m.handleAuthExpired()
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
+	case modal.LLMUndoneMsg:
+		if msg.Err != nil && client.IsAuthError(msg.Err) {
+			return m.handleAuthExpired()
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
+	case modal.LLMUndoExpiredMsg:
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
 	case modal.LLMProfileDefaultSetMsg:
 		if msg.Err != nil && client.IsAuthError(msg.Err) {
 			return m.handleAuthExpired()
 		}
+		if msg.Err == nil {
+			m.statusBar.SetActiveProfile(msg.Profile)
+		}
 		if m.modal.IsOpen() {
 			_, cmd := m.modal.UpdateMsg(msg)
 			return m, cmd
@@ -412,6 +725,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	case modal.TasksPageLoadedMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
+	case modal.LLMProfileOrderChangedMsg:
+		if m.config.ProfileOrders == nil {
+			m.config.ProfileOrders = make(map[string][]string)
+		}
+		m.config.ProfileOrders[msg.Integration] = msg.Order
+		_ = m.config.Save() // Best effort save
+		return m, nil
+
+	case modal.WorkflowFilterChangedMsg:
+		m.config.EnabledWorkflowsOnly = msg.EnabledOnly
+		_ = m.config.Save() // Best effort save
+		return m, nil
+
+	case modal.TaskTimeFormatChangedMsg:
+		m.config.AbsoluteTaskTimes = msg.Absolute
+		_ = m.config.Save() // Best effort save
+		return m, nil
+
+	case modal.TaskRerunRequestMsg:
+		m.modal.Close()
+		return m.rerunWorkflow(msg.Workflow)
+
 	case modal.TaskDetailLoadedMsg:
 		if msg.Error != nil && client.IsAuthError(msg.Error) {
 			return m.handleAuthExpired()
@@ -445,31 +789,92 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case modal.TasksDismissedMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
+		}
+		var cmds []tea.Cmd
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			cmds = append(cmds, cmd)
+		}
+		// Fetch task status immediately to update status bar counts
+		if msg.Dismissed > 0 {
+			cmds = append(cmds, m.doFetchTaskStatus())
+		}
+		return m, tea.Batch(cmds...)
+
 	case components.ConfirmationExpiredMsg:
 		if m.modal.IsOpen() {
 			_, cmd := m.modal.UpdateMsg(msg)
 			return m, cmd
 		}
 
+	case modal.TasksClockTickMsg:
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case modal.RunExportedMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
+		}
+		// Exports from the tasks modal report there
+		if _, ok := m.modal.Active.(*modal.TasksModal); ok {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+		if msg.Error != nil {
+			m.chat.AddSystemMessage("Export failed: " + msg.Error.Error())
+		} else {
+			m.chat.AddSystemMessage("Exported run " + msg.RunID + " to " + msg.Path)
+		}
+		return m, nil
+
+	case components.ClipboardCopiedMsg:
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
 	case modal.SettingsSavedMsg:
 		if msg.Error == nil && msg.Config != nil {
-			// Update app config and client with new server URL
+			urlChanged := msg.Config.ServerURL != m.config.ServerURL
 			m.config = msg.Config
-			m.client.SetBaseURL(msg.Config.ServerURL)
-			// Clear token since it's tied to the old server
-			m.client.SetToken("")
-			m.config.Token = ""
-			m.config.TokenExp = ""
-			// Save config without token
-			_ = m.config.Save()
-			// Close modal and reset to login state
-			m.modal.Close()
-			m.state = StateLogin
-			m.login = login.New(false, msg.Config.ServerURL)
-			m.login.SetSize(m.width, m.height)
-			m.statusBar.SetState(status.StateDisconnected)
+			m.client = m.newClient(msg.Config.ServerURL)
 			m.statusBar.SetServerURL(msg.Config.ServerURL)
-			return m, nil
+
+			if urlChanged {
+				// Clear token since it's tied to the old server
+				m.config.Token = ""
+				m.config.TokenExp = ""
+				// Save config without token
+				_ = m.config.Save()
+				// Close modal and reset to login state
+				m.modal.Close()
+				m.state = StateLogin
+				m.login = m.newLogin(false, msg.Config.ServerURL)
+				m.login.SetSize(m.width, m.height)
+				m.statusBar.SetState(status.StateDisconnected)
+				return m, nil
+			}
+
+			// Same server: keep the session and re-check the connection
+			m.client.SetToken(m.config.Token)
+			m.statusBar.SetState(status.StateConnecting)
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, tea.Batch(cmd, m.doHealthCheck(), m.startStatusTick())
+		}
+		if m.modal.IsOpen() {
+			_, cmd := m.modal.UpdateMsg(msg)
+			return m, cmd
+		}
+
+	case modal.ServerInfoLoadedMsg:
+		if msg.Error != nil && client.IsAuthError(msg.Error) {
+			return m.handleAuthExpired()
 		}
 		if m.modal.IsOpen() {
 			_, cmd := m.modal.UpdateMsg(msg)
@@ -521,8 +926,11 @@ func (m Model) updateLogin(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if serverURL == "" {
 			serverURL = m.config.ServerURL
 		}
-		m.client = client.New(serverURL)
+		m.client = m.newClient(serverURL)
 
+		if m.login.TokenMode() {
+			return m, m.doTokenLogin(m.login.Token())
+		}
 		return m, m.doLogin(m.login.Username(), m.login.Password())
 	}
 
@@ -533,6 +941,13 @@ func (m Model) updateLogin(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Pasted text always goes straight to the input, never to key bindings
+	if msg.Paste {
+		var cmd tea.Cmd
+		m.chat, cmd = m.chat.Update(msg)
+		return m, cmd
+	}
+
 	// Handle Shift+C to cancel the tracked workflow
 	if msg.String() == "C" && m.workflowHintActive && m.workflowHintRunID != "" {
 		runID := m.workflowHintRunID
@@ -555,6 +970,11 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.chat.CompleteInput()
 			return m, nil
 		case "enter":
+			if !m.chat.HasAutocompleteSuggestions() {
+				// Only a hint is showing; handle Enter normally
+				m.chat.HideAutocomplete()
+				break
+			}
 			// Enter behavior depends on prefix type
 			prefix := m.chat.AutocompletePrefix()
 			switch prefix {
@@ -582,7 +1002,8 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.chat.CompleteInput()
 			}
 			return m, nil
-		case "esc":
+		}
+		if m.keys.Is(msg, keys.Cancel) {
 			m.chat.HideAutocomplete()
 			return m, nil
 		}
@@ -591,9 +1012,9 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle Tab to show/cycle autocomplete
 	if msg.String() == "tab" && !m.chat.IsStreaming() {
 		prefix, partial := m.chat.GetInputPrefix()
-		suggestions := m.getSuggestions(prefix, partial)
-		if len(suggestions) > 0 {
-			m.chat.ShowAutocomplete(prefix, partial, suggestions)
+		suggestions, message := m.getSuggestions(prefix, partial)
+		if len(suggestions) > 0 || message != "" {
+			m.chat.ShowAutocomplete(prefix, partial, suggestions, message)
 		}
 		return m, nil
 	}
@@ -601,7 +1022,7 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle Enter to send message
 	if msg.String() == "enter" && !m.chat.IsStreaming() {
 		input := m.chat.InputValue()
-		if input != "" {
+		if input != "" || m.attachment != nil {
 			// Check for slash command
 			if cmd := chat.ParseCommand(input); cmd != nil {
 				m.chat.ClearInput()
@@ -615,23 +1036,47 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m.startWorkflow(workflowName)
 			}
 
-			m.chat.AddUserMessage(input)
 			m.chat.ClearInput()
-			m.chat.AddHubMessage()
 
 			// Route based on @ prefix and current target
 			startsWithAt := len(input) > 0 && input[0] == '@'
 
+			m.lastPrompt = input
+			if m.attachment != nil {
+				m.lastPrompt = m.attachment.appendTo(input)
+				input = strings.TrimSpace(input + "\n\nAttached: " + m.attachment.label())
+				m.attachment = nil
+				m.chat.SetAttachment("")
+			}
+			m.chat.AddUserMessage(input)
+			thinkingCmd := m.chat.AddHubMessage()
 			if startsWithAt {
 				// @ prefix: always route through /ask (let hub-core decide)
-				return m, m.doAsk(input)
+				m.lastPromptTarget = ""
 			} else if m.context.Type == "assistant" && m.context.Target != "" {
 				// No @ prefix but in assistant context: send directly to assistant
-				return m, m.doAssistantChat(m.context.Target, input)
+				m.lastPromptTarget = m.context.Target
 			} else {
 				// No @ prefix, no assistant context: send to /ask
-				return m, m.doAsk(input)
+				m.lastPromptTarget = ""
 			}
+			return m, tea.Batch(thinkingCmd, m.sendLastPrompt())
+		}
+		return m, nil
+	}
+
+	// Handle F5 to refresh
+	if m.keys.Is(msg, keys.Refresh) {
+		return m.startRefresh()
+	}
+
+	// Handle Ctrl+R to regenerate the last hub reply
+	if m.keys.Is(msg, keys.Regenerate) && !m.chat.IsStreaming() {
+		if m.lastPrompt != "" && m.chat.RemoveLastHubMessage() {
+			// The prompt is routed again and gets a new note
+			m.chat.RemoveLastNote(routeNotePrefix)
+			thinkingCmd := m.chat.AddHubMessage()
+			return m, tea.Batch(thinkingCmd, m.sendLastPrompt())
 		}
 		return m, nil
 	}
@@ -644,9 +1089,9 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if !m.chat.IsStreaming() {
 		prefix, partial := m.chat.GetInputPrefix()
 		if prefix != chat.PrefixNone {
-			suggestions := m.getSuggestions(prefix, partial)
-			if len(suggestions) > 0 {
-				m.chat.ShowAutocomplete(prefix, partial, suggestions)
+			suggestions, message := m.getSuggestions(prefix, partial)
+			if len(suggestions) > 0 || message != "" {
+				m.chat.ShowAutocomplete(prefix, partial, suggestions, message)
 			} else {
 				m.chat.HideAutocomplete()
 			}
@@ -658,7 +1103,9 @@ func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Model) getSuggestions(prefix chat.InputPrefix, partial string) []string {
+// getSuggestions returns the completions for partial. If there is no data to
+// complete from, it returns a message explaining why instead.
+func (m Model) getSuggestions(prefix chat.InputPrefix, partial string) ([]string, string) {
 	var items []string
 
 	switch prefix {
@@ -673,51 +1120,96 @@ func (m Model) getSuggestions(prefix chat.InputPrefix, partial string) []string
 	case chat.PrefixCommand:
 		items = chat.KnownCommands
 	default:
-		return nil
+		return nil, ""
 	}
 
-	return chat.FilterSuggestions(items, partial)
+	if len(items) == 0 {
+		if !m.statusBar.IsConnected() {
+			return nil, "(not connected — try /refresh)"
+		}
+		if m.cache.LastUpdate.IsZero() {
+			return nil, "(not loaded yet — try /refresh)"
+		}
+	}
+	return chat.FilterSuggestions(items, partial), ""
 }
 
 func (m Model) handleCommand(cmd *chat.Command) (tea.Model, tea.Cmd) {
 	switch cmd.Name {
 	case "exit":
-		m.quitting = true
-		return m, tea.Quit
+		return m, m.quit()
 
 	case "clear":
 		m.chat.ClearMessages()
 		return m, nil
 
 	case "hub":
-		m.context.Type = "hub"
-		m.context.Target = ""
-		m.statusBar.SetContext("hub", "")
-		m.chat.SetInContext(false)
+		m.setContext("hub", "")
 		m.chat.AddSystemMessage("Returned to hub context.")
 		return m, nil
 
 	case "help":
-		return m, m.modal.Open(modal.NewHelpModal())
+		return m, m.modal.Open(modal.NewHelpModal(m.keys))
 
 	case "refresh":
-		m.chat.AddSystemMessage("Refreshing cache...")
-		return m, m.doRefreshCache()
+		return m.startRefresh()
+
+	case "reconnect":
+		return m.startReconnect()
+
+	case "export-run":
+		id := strings.TrimSpace(cmd.Args)
+		if id == "" {
+			m.chat.AddSystemMessage("Usage: /export-run <run-id>")
+			return m, nil
+		}
+		m.chat.AddSystemMessage("Exporting run " + id + "...")
+		return m, modal.ExportRun(m.client, id)
+
+	case "rerun":
+		if m.lastWorkflow == "" {
+			m.chat.AddSystemMessage("No workflow has been run yet.")
+			return m, nil
+		}
+		return m.rerunWorkflow(m.lastWorkflow)
 
 	case "settings":
-		return m, m.modal.Open(modal.NewSettingsModal(m.config, m.statusBar.IsConnected()))
+		return m, m.modal.Open(modal.NewSettingsModal(m.client, m.config, m.statusBar.IsConnected()))
 
 	case "modules":
 		return m, m.modal.Open(modal.NewModulesModal(m.client))
 
 	case "workflows":
-		return m, m.modal.Open(modal.NewWorkflowsModal(m.client))
+		return m, m.modal.Open(m.newWorkflowsModal())
 
 	case "integrations":
-		return m, m.modal.Open(modal.NewIntegrationsModal(m.client))
+		// An argument opens that integration's configuration directly
+		integrations := modal.NewIntegrationsModalForTarget(m.client, m.config.ConfirmTimeout(), strings.TrimSpace(cmd.Args))
+		integrations.SetProfileOrders(m.config.ProfileOrders)
+		return m, m.modal.Open(integrations)
 
 	case "tasks":
-		return m, m.modal.Open(modal.NewTasksModal(m.client))
+		return m, m.modal.Open(m.newTasksModal())
+
+	case "model":
+		return m, m.doModelCommand(strings.TrimSpace(cmd.Args))
+
+	case "whoami":
+		m.chat.AddSystemMessage(m.whoami())
+		return m, nil
+
+	case "attach":
+		m.attachFile(strings.TrimSpace(cmd.Args))
+		return m, nil
+
+	case "raw":
+		m.chat.SetRaw(!m.chat.Raw())
+		if m.chat.Raw() {
+			m.chat.AddSystemMessage("Showing replies as raw markdown. Type /raw again to render them.")
+		} else {
+			m.chat.AddSystemMessage("Showing rendered markdown.")
+		}
+		return m, nil
 
 	default:
 		if !chat.IsValidCommand(cmd.Name) {
@@ -735,6 +1227,7 @@ func (m Model) handleLoginResult(msg LoginResultMsg) (tea.Model, tea.Cmd) {
 
 	// Store token and server URL in config
 	m.config.ServerURL = m.client.BaseURL()
+	m.config.AddRecentServer(m.config.ServerURL)
 	m.config.Token = msg.Token
 	m.config.TokenExp = msg.ExpiresAt
 	if err := m.config.Save(); err != nil {
@@ -754,9 +1247,70 @@ func (m Model) handleLoginResult(msg LoginResultMsg) (tea.Model, tea.Cmd) {
 	m.chat.SetSize(m.width, m.height-1)
 	m.chat.FocusInput()
 
+	if msg.Unverified {
+		m.chat.AddSystemMessage("This server can't verify API tokens, so the token is unchecked. " +
+			"You'll be asked to log in again if hub-core rejects it.")
+	}
+
+	return m, tea.Batch(m.doHealthCheck(), m.startStatusTick())
+}
+
+// startStatusTick starts the connecting animation unless it is already running.
+func (m *Model) startStatusTick() tea.Cmd {
+	if m.statusTicking {
+		return nil
+	}
+	m.statusTicking = true
+	return statusTick(0)
+}
+
+// statusTick schedules the next frame of the connecting animation.
+func statusTick(frame int) tea.Cmd {
+	return tea.Tick(statusTickInterval, func(time.Time) tea.Msg {
+		return StatusTickMsg{Frame: frame}
+	})
+}
+
+// startRefreshAgeTick keeps the status bar's cache age current unless it
+// already is.
+func (m *Model) startRefreshAgeTick() tea.Cmd {
+	if m.refreshAgeTicking {
+		return nil
+	}
+	m.refreshAgeTicking = true
+	return refreshAgeTick()
+}
+
+// refreshAgeTick schedules the next redraw of the cache age.
+func refreshAgeTick() tea.Cmd {
+	return tea.Tick(refreshAgeInterval, func(time.Time) tea.Msg {
+		return RefreshAgeTickMsg{}
+	})
+}
+
+// startRefresh re-runs the health check, which refreshes the cache and
+// LLM profiles once the server is reachable.
+func (m Model) startRefresh() (tea.Model, tea.Cmd) {
+	if m.refreshing {
+		return m, nil
+	}
+	m.refreshing = true
+	m.chat.AddSystemMessage("Refreshing...")
 	return m, m.doHealthCheck()
 }
 
+// startReconnect retries the connection with the connecting animation
+// showing, refreshing the cache if the server is reachable.
+func (m Model) startReconnect() (tea.Model, tea.Cmd) {
+	if m.refreshing {
+		return m, nil
+	}
+	m.refreshing = true
+	m.statusBar.SetState(status.StateConnecting)
+	m.chat.AddSystemMessage("Reconnecting to " + m.client.BaseURL() + "...")
+	return m, tea.Batch(m.doHealthCheck(), m.startStatusTick())
+}
+
 func (m Model) handleHealthCheck(msg HealthCheckMsg) (tea.Model, tea.Cmd) {
 	// Update settings modal if open
 	if settingsModal, ok := m.modal.Active.(*modal.SettingsModal); ok {
@@ -772,6 +1326,10 @@ func (m Model) handleHealthCheck(msg HealthCheckMsg) (tea.Model, tea.Cmd) {
 		)
 	}
 	m.statusBar.SetState(status.StateDisconnected)
+	if m.refreshing {
+		m.refreshing = false
+		m.chat.AddSystemMessage("Refresh failed: " + msg.Error + ". Type /reconnect to try again.")
+	}
 	// If we were in login, show the error
 	if m.state == StateLogin {
 		m.login.SetError(msg.Error)
@@ -780,6 +1338,8 @@ func (m Model) handleHealthCheck(msg HealthCheckMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleCacheRefresh(msg CacheRefreshMsg) (tea.Model, tea.Cmd) {
+	refreshing := m.refreshing
+	m.refreshing = false
 	if !msg.Success {
 		m.chat.AddSystemMessage("Cache refresh failed: " + msg.Error)
 		return m, nil
@@ -787,6 +1347,7 @@ func (m Model) handleCacheRefresh(msg CacheRefreshMsg) (tea.Model, tea.Cmd) {
 
 	// Update cache with fresh data
 	m.cache.LastUpdate = time.Now()
+	m.statusBar.SetLastRefresh(m.cache.LastUpdate)
 
 	// Convert names back to full structs (we only pass names in the message)
 	m.cache.Assistants = make([]client.Assistant, len(msg.Assistants))
@@ -802,7 +1363,75 @@ func (m Model) handleCacheRefresh(msg CacheRefreshMsg) (tea.Model, tea.Cmd) {
 		m.cache.Modules[i] = client.Module{Name: name}
 	}
 
-	return m, nil
+	m.statusBar.SetActiveProfile(msg.ActiveProfile)
+
+	if refreshing {
+		m.chat.AddSystemMessage(fmt.Sprintf("Refreshed: %d assistants, %d workflows, %d modules, %d LLM profiles.",
+			len(msg.Assistants), len(msg.Workflows), len(msg.Modules), len(msg.Profiles)))
+	}
+
+	return m, tea.Batch(m.restoreSavedContext(), m.startRefreshAgeTick())
+}
+
+// restoreSavedContext switches to the context saved by the last session,
+// falling back to hub if its assistant no longer exists.
+func (m *Model) restoreSavedContext() tea.Cmd {
+	saved := m.restoreContext
+	m.restoreContext = Context{}
+	if saved.Type != "assistant" || saved.Target == "" {
+		return nil
+	}
+
+	for _, a := range m.cache.Assistants {
+		if a.Name == saved.Target {
+			return func() tea.Msg {
+				return RouteMsg{Type: saved.Type, Target: saved.Target}
+			}
+		}
+	}
+
+	m.chat.AddSystemMessage("Assistant @" + saved.Target + " no longer exists. Staying in hub context.")
+	m.saveContext(Context{Type: "hub"})
+	return nil
+}
+
+// quit saves state and ends the program.
+func (m *Model) quit() tea.Cmd {
+	m.quitting = true
+	m.Shutdown()
+	return tea.Quit
+}
+
+// Shutdown stops any streaming reply and saves state that should outlive
+// the session: the active context and any config changes not yet saved.
+// It runs when the user quits and again from main once the program exits,
+// which covers exits that skip the quit keys; only the first call does
+// anything.
+func (m *Model) Shutdown() {
+	if m.shutDown {
+		return
+	}
+	m.shutDown = true
+	if m.cancelAsk != nil {
+		m.cancelAsk()
+	}
+	if m.state == StateMain {
+		m.saveContext(m.context)
+	}
+	_ = m.config.Save() // Best effort save
+}
+
+// saveContext remembers an assistant context in the config for the next start.
+func (m *Model) saveContext(ctx Context) {
+	if ctx.Type != "assistant" || ctx.Target == "" {
+		ctx = Context{}
+	}
+	if m.config.ContextType == ctx.Type && m.config.ContextTarget == ctx.Target {
+		return
+	}
+	m.config.ContextType = ctx.Type
+	m.config.ContextTarget = ctx.Target
+	_ = m.config.Save() // Best effort save
 }
 
 func (m Model) handleAuthExpired() (tea.Model, tea.Cmd) {
@@ -813,10 +1442,11 @@ func (m Model) handleAuthExpired() (tea.Model, tea.Cmd) {
 
 	// Close any open modal
 	m.modal.Close()
+	m.refreshing = false
 
 	// Reset to login state
 	m.state = StateLogin
-	m.login = login.New(false, m.config.ServerURL)
+	m.login = m.newLogin(false, m.config.ServerURL)
 	m.login.SetSize(m.width, m.height)
 	m.login.SetError("Session expired. Please log in again.")
 
@@ -839,6 +1469,30 @@ func (m Model) doLogin(username, password string) tea.Cmd {
 	}
 }
 
+// doTokenLogin verifies an API token and logs in with it directly.
+func (m Model) doTokenLogin(token string) tea.Cmd {
+	return func() tea.Msg {
+		m.client.SetToken(token)
+		err := m.client.VerifyToken()
+		unverified := errors.Is(err, client.ErrTokenUnverified)
+		if err != nil && !unverified {
+			return LoginResultMsg{Success: false, Error: err.Error()}
+		}
+
+		// Only JWTs carry a readable expiry
+		var expiresAt string
+		if expiry := client.TokenExpiry(token); !expiry.IsZero() {
+			expiresAt = expiry.Format(time.RFC3339)
+		}
+		return LoginResultMsg{
+			Success:    true,
+			Token:      token,
+			ExpiresAt:  expiresAt,
+			Unverified: unverified,
+		}
+	}
+}
+
 func (m Model) doHealthCheck() tea.Cmd {
 	return func() tea.Msg {
 		if err := m.client.Health(); err != nil {
@@ -888,59 +1542,283 @@ func (m Model) doRefreshCache() tea.Cmd {
 			moduleNames = append(moduleNames, m.Name)
 		}
 
+		profiles, active := m.fetchLLMProfiles()
 		return CacheRefreshMsg{
-			Success:    true,
-			Assistants: assistantNames,
-			Workflows:  workflowNames,
-			Modules:    moduleNames,
+			Success:       true,
+			Assistants:    assistantNames,
+			Workflows:     workflowNames,
+			Modules:       moduleNames,
+			ActiveProfile: active,
+			Profiles:      profiles,
+		}
+	}
+}
+
+// findLLMIntegration returns the name of the first integration with an LLM config.
+// Returns "" if there is none.
+func (m *Model) findLLMIntegration() (string, error) {
+	integrations, err := m.client.ListIntegrations()
+	if err != nil {
+		return "", err
+	}
+	for _, integration := range integrations {
+		if integration.ConfigType == "llm" {
+			return integration.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// fetchLLMProfiles returns the profile names and default profile of the first
+// LLM integration. Failures are ignored since the profiles are informational.
+func (m *Model) fetchLLMProfiles() ([]string, string) {
+	integration, err := m.findLLMIntegration()
+	if err != nil || integration == "" {
+		return nil, ""
+	}
+	list, err := m.client.ListLLMProfiles(integration)
+	if err != nil {
+		return nil, ""
+	}
+	var names []string
+	var active string
+	for _, p := range list.Profiles {
+		names = append(names, p.Name)
+		if p.IsDefault {
+			active = p.Name
+		}
+	}
+	return names, active
+}
+
+// doModelCommand lists LLM profiles, or sets the named profile as default.
+func (m Model) doModelCommand(name string) tea.Cmd {
+	return func() tea.Msg {
+		integration, err := m.findLLMIntegration()
+		if err != nil {
+			return ModelCommandMsg{Error: err}
+		}
+		if integration == "" {
+			return ModelCommandMsg{Message: "No LLM integration configured."}
+		}
+
+		list, err := m.client.ListLLMProfiles(integration)
+		if err != nil {
+			return ModelCommandMsg{Error: err}
 		}
+
+		// No argument: list profiles
+		if name == "" {
+			if len(list.Profiles) == 0 {
+				return ModelCommandMsg{Message: "No LLM profiles configured. Type /integrations " + integration + " to add one."}
+			}
+			lines := []string{"LLM profiles:"}
+			for _, p := range list.Profiles {
+				mark := "  "
+				if p.IsDefault {
+					mark = "★ "
+				}
+				lines = append(lines, "  "+mark+p.Name+" ("+p.Provider+"/"+p.Model+")")
+			}
+			return ModelCommandMsg{Message: strings.Join(lines, "\n")}
+		}
+
+		for _, p := range list.Profiles {
+			if p.Name != name {
+				continue
+			}
+			if p.IsDefault {
+				return ModelCommandMsg{Message: name + " is already the default profile."}
+			}
+			if err := m.client.SetDefaultLLMProfile(integration, name); err != nil {
+				return ModelCommandMsg{Error: err}
+			}
+			return ModelCommandMsg{Message: "Default profile set to " + name + ".", Profile: name}
+		}
+
+		return ModelCommandMsg{Message: "Unknown profile: " + name + ". Type /model to list profiles."}
+	}
+}
+
+// setContext switches the conversation context. Unless transcripts are
+// shared, the chat swaps to the new context's transcript; a reply still
+// streaming moves along with the prompt that started it.
+func (m *Model) setContext(typ, target string) {
+	next := Context{Type: typ, Target: target}
+	prevKey, nextKey := m.context.transcriptKey(), next.transcriptKey()
+
+	m.context = next
+	m.statusBar.SetContext(typ, target)
+	m.chat.SetInContext(typ == "assistant" && target != "")
+	m.saveContext(next)
+
+	if prevKey == nextKey || m.config.SharedTranscript {
+		return
+	}
+
+	// The hint is tracked by message index in the current transcript
+	m.clearWorkflowHintWithUpdate()
+
+	pending := m.chat.TakePendingExchange()
+	state := m.saved[nextKey]
+	delete(m.saved, nextKey)
+
+	prev := contextState{transcript: m.chat.SwapTranscript(state.transcript)}
+	if len(pending) > 0 {
+		// The last prompt left with its reply
+		m.chat.AppendMessages(pending)
+	} else {
+		prev.lastPrompt, prev.lastPromptTarget = m.lastPrompt, m.lastPromptTarget
+		m.lastPrompt, m.lastPromptTarget = state.lastPrompt, state.lastPromptTarget
+	}
+	m.saved[prevKey] = prev
+}
+
+// attachFile handles /attach. With a path it attaches the file to the next
+// message, replacing any pending attachment; without one it removes the
+// pending attachment.
+func (m *Model) attachFile(path string) {
+	if path == "" {
+		if m.attachment == nil {
+			m.chat.AddSystemMessage("Usage: /attach <path>")
+			return
+		}
+		m.chat.AddSystemMessage("Removed attachment " + m.attachment.name + ".")
+		m.attachment = nil
+		m.chat.SetAttachment("")
+		return
+	}
+
+	a, err := readAttachment(path)
+	if err != nil {
+		m.chat.AddSystemMessage("Cannot attach " + path + ": " + err.Error())
+		return
 	}
+	m.attachment = a
+	m.chat.SetAttachment(a.label())
+}
+
+// whoami describes the logged-in user, server and token for /whoami.
+func (m Model) whoami() string {
+	user := "(unknown)"
+	issuer := ""
+	if claims := client.TokenClaims(m.config.Token); claims != nil {
+		if u := claims.User(); u != "" {
+			user = u
+		}
+		issuer = claims.Issuer
+	} else if m.config.Token != "" {
+		user = "(API token)"
+	}
+
+	expires := "never"
+	if expiry := client.TokenExpiry(m.config.Token); !expiry.IsZero() {
+		expires = expiry.Local().Format("2006-01-02 15:04")
+	}
+
+	lines := []string{
+		"User: " + user,
+		"Server: " + m.config.ServerURL,
+		"Token expires: " + expires,
+	}
+	if issuer != "" {
+		lines = append(lines, "Issuer: "+issuer)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sendLastPrompt re-issues the last prompt to the target it was originally routed to.
+func (m *Model) sendLastPrompt() tea.Cmd {
+	if m.lastPromptTarget != "" {
+		return m.doAssistantChat(m.lastPromptTarget, m.lastPrompt)
+	}
+	return m.doAsk(m.lastPrompt)
 }
 
 func (m *Model) doAsk(message string) tea.Cmd {
 	ctx, cancel := context.WithCancel(context.Background())
 	m.cancelAsk = cancel
 
-	return func() tea.Msg {
+	stream := make(chan tea.Msg, streamBufferSize)
+	go func() {
+		defer close(stream)
+
 		callbacks := client.AskCallbacks{
 			OnRoute: func(route client.RouteInfo) {
-				if m.program != nil {
-					m.program.Send(RouteMsg{Type: route.Type, Target: route.Target})
-				}
+				stream <- RouteMsg{Type: route.Type, Target: route.Target, Ask: true}
 			},
 			OnChunk: func(chunk string) {
-				if m.program != nil {
-					m.program.Send(StreamChunkMsg{Content: chunk})
-				}
+				stream <- StreamChunkMsg{Content: chunk}
 			},
 		}
 
 		resp, err := m.client.Ask(ctx, message, callbacks)
 		if err != nil {
-			return StreamDoneMsg{Error: err}
+			stream <- StreamDoneMsg{Error: err}
+			return
 		}
 
 		// Handle status-based response
 		switch resp.Status {
 		case client.StatusNeedsInput:
-			return AskNeedsInputMsg{
+			stream <- AskNeedsInputMsg{
 				Target: resp.Target,
 				Schema: resp.Schema,
 			}
 		case client.StatusExecuted:
-			return AskExecutedMsg{
+			stream <- AskExecutedMsg{
 				Target: resp.Target,
 				Result: resp.Result,
+				RunID:  resp.RunID,
 			}
 		case client.StatusError:
-			return AskErrorMsg{
+			stream <- AskErrorMsg{
 				Target: resp.Target,
 				Error:  resp.Error,
 			}
 		default:
 			// Legacy response format (assistant chat, etc.) - no status field
-			return StreamDoneMsg{Error: nil}
+			stream <- StreamDoneMsg{RunID: resp.RunID}
 		}
+	}()
+
+	return waitForStream(stream)
+}
+
+// loadReplyRun fetches the workflow run behind the last chat message so
+// its steps can be shown under it. Returns nil if there was no run.
+func (m *Model) loadReplyRun(runID string) tea.Cmd {
+	if runID == "" {
+		return nil
+	}
+	idx := m.chat.MessageCount() - 1
+	// The run just produced this reply, so it may not be written yet
+	retry := client.DefaultRunRetry
+	retry.Finalizing = true
+	return func() tea.Msg {
+		run, err := m.client.GetRunWithRetry(runID, retry)
+		return ReplyRunLoadedMsg{MsgIdx: idx, Run: run, Error: err}
+	}
+}
+
+// replySteps converts a run's step results for display under a reply.
+func replySteps(results []client.StepResult) []chat.Step {
+	steps := make([]chat.Step, len(results))
+	for i, r := range results {
+		steps[i] = chat.Step{Name: r.StepName, Success: r.Success, Error: r.Error}
+	}
+	return steps
+}
+
+// waitForStream returns a command that delivers the next message from a stream.
+// Each delivered message re-arms the wait until the stream is closed.
+func waitForStream(stream <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return nil
+		}
+		return streamEventMsg{Msg: msg, stream: stream}
 	}
 }
 
@@ -974,6 +1852,7 @@ func (m *Model) doAskWithParams(target string, params map[string]interface{}) te
 			return AskExecutedMsg{
 				Target: resp.Target,
 				Result: resp.Result,
+				RunID:  resp.RunID,
 			}
 		case client.StatusError:
 			return AskErrorMsg{
@@ -996,38 +1875,52 @@ func (m *Model) doAssistantChat(assistant, message string) tea.Cmd {
 	ctx, cancel := context.WithCancel(context.Background())
 	m.cancelAsk = cancel
 
-	return func() tea.Msg {
+	stream := make(chan tea.Msg, streamBufferSize)
+	go func() {
+		defer close(stream)
+
 		callbacks := client.AssistantChatCallbacks{
 			OnAssistant: func(info client.AssistantInfo) {
 				// Confirm we're talking to the right assistant
-				if m.program != nil {
-					m.program.Send(RouteMsg{Type: "assistant", Target: info.Name})
-				}
+				stream <- RouteMsg{Type: "assistant", Target: info.Name}
 			},
 			OnChunk: func(chunk string) {
-				if m.program != nil {
-					m.program.Send(StreamChunkMsg{Content: chunk})
-				}
+				stream <- StreamChunkMsg{Content: chunk}
 			},
 		}
 
 		_, err := m.client.AssistantChat(ctx, assistant, message, callbacks)
-		return StreamDoneMsg{Error: err}
-	}
+		stream <- StreamDoneMsg{Error: err}
+	}()
+
+	return waitForStream(stream)
 }
 
 // startWorkflow initiates a workflow with cancel hint tracking.
 func (m Model) startWorkflow(name string) (tea.Model, tea.Cmd) {
+	return m.beginWorkflow(name, "Starting workflow: ", false)
+}
+
+// rerunWorkflow starts another run of a workflow that ran before.
+// The new run ID is reported once it starts.
+func (m Model) rerunWorkflow(name string) (tea.Model, tea.Cmd) {
+	return m.beginWorkflow(name, "Re-running workflow: ", true)
+}
+
+// beginWorkflow announces and triggers a workflow run, showing the cancel
+// hint until it starts.
+func (m Model) beginWorkflow(name, announce string, rerun bool) (tea.Model, tea.Cmd) {
 	// Clear any previous hint
 	m.clearWorkflowHint()
+	m.lastWorkflow = name
 
 	// Add system message with cancel hint and track its index
-	m.chat.AddSystemMessage("Starting workflow: " + name + "  [Shift+C to cancel]")
+	m.chat.AddSystemMessage(announce + name + "  [Shift+C to cancel]")
 	m.workflowHintMsgIdx = m.chat.MessageCount() - 1
 	m.workflowHintActive = true
 	// workflowHintRunID will be set when WorkflowStartedMsg arrives
 
-	return m, m.doRunWorkflow(name)
+	return m, m.doRunWorkflow(name, rerun)
 }
 
 // clearWorkflowHint removes the cancel hint from the tracked message.
@@ -1048,7 +1941,7 @@ func (m *Model) clearWorkflowHint() {
 	}
 }
 
-func (m Model) doRunWorkflow(name string) tea.Cmd {
+func (m Model) doRunWorkflow(name string, rerun bool) tea.Cmd {
 	return func() tea.Msg {
 		runID, err := m.client.RunWorkflow(name)
 		if err != nil {
@@ -1057,7 +1950,7 @@ func (m Model) doRunWorkflow(name string) tea.Cmd {
 			}
 			return WorkflowErrorMsg{Name: name, Error: err.Error()}
 		}
-		return WorkflowStartedMsg{Name: name, RunID: runID}
+		return WorkflowStartedMsg{Name: name, RunID: runID, Rerun: rerun}
 	}
 }
 
@@ -1066,6 +1959,9 @@ func (m Model) handleWorkflowStarted(msg WorkflowStartedMsg) (tea.Model, tea.Cmd
 	if m.workflowHintActive {
 		m.workflowHintRunID = msg.RunID
 	}
+	if msg.Rerun {
+		m.chat.AddSystemMessage("Started run " + msg.RunID + " of " + msg.Name)
+	}
 
 	// Add to running tasks
 	m.tasks.Running = append(m.tasks.Running, Run{
@@ -1278,9 +2174,47 @@ func (m Model) handleTaskStatus(msg TaskStatusMsg) (tea.Model, tea.Cmd) {
 	// Update status bar
 	m.updateTaskCounts()
 
+	// Notify about runs that started needing attention since the last
+	// poll. The first poll only records what's there, so a restart doesn't
+	// notify about old runs.
+	attention := make(map[string]bool)
+	var newlyNeeding []string
+	for _, r := range msg.Runs {
+		if !r.NeedsAttention {
+			continue
+		}
+		attention[r.ID] = true
+		if m.tasks.attention != nil && !m.tasks.attention[r.ID] {
+			newlyNeeding = append(newlyNeeding, r.Workflow)
+		}
+	}
+	m.tasks.attention = attention
+
+	if len(newlyNeeding) > 0 && m.config.NotifyOnAttention {
+		return m, notifyAttention(m.term, newlyNeeding)
+	}
 	return m, nil
 }
 
+// notifyAttention rings the terminal bell and sends an OSC 9 desktop
+// notification naming the workflows that need attention. Terminals that
+// don't support OSC 9 ignore it and just ring the bell. It writes through
+// the terminal so it can't land in the middle of a frame.
+func notifyAttention(term *Terminal, workflows []string) tea.Cmd {
+	if term == nil {
+		return nil
+	}
+	names := make([]string, len(workflows))
+	for i, w := range workflows {
+		names[i] = notifySafe(w, maxNotifyNameLen)
+	}
+	text := notifySafe("Needs attention: "+strings.Join(names, ", "), maxNotifyTextLen)
+	return func() tea.Msg {
+		_, _ = fmt.Fprintf(term, "\x1b]9;%s\x07\a", text)
+		return nil
+	}
+}
+
 func (m *Model) updateTaskCounts() {
 	// Count items needing attention across all categories
 	needsAttention := 0
@@ -1334,10 +2268,12 @@ func convertClientResult(cr *client.RunResult) *RunResult {
 	var steps []StepResult
 	for _, s := range cr.Steps {
 		steps = append(steps, StepResult{
-			StepName: s.StepName,
-			Success:  s.Success,
-			Output:   s.Output,
-			Error:    s.Error,
+			StepName:  s.StepName,
+			Success:   s.Success,
+			Output:    s.Output,
+			Error:     s.Error,
+			StartedAt: formatOptionalTime(s.StartedAt),
+			EndedAt:   formatOptionalTime(s.EndedAt),
 		})
 	}
 	return &RunResult{
@@ -1349,12 +2285,25 @@ func convertClientResult(cr *client.RunResult) *RunResult {
 	}
 }
 
+// formatOptionalTime formats t as RFC 3339, or "" if it wasn't reported.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 // View renders the UI.
 func (m Model) View() string {
 	if m.quitting {
 		return ""
 	}
 
+	// Layouts break down below a minimum width, so say so instead
+	if m.width > 0 && m.width < minWidth {
+		return m.renderTooNarrow()
+	}
+
 	switch m.state {
 	case StateLogin:
 		return m.login.View()
@@ -1365,6 +2314,36 @@ func (m Model) View() string {
 	return ""
 }
 
+// renderTooNarrow explains that the terminal needs to be wider.
+func (m Model) renderTooNarrow() string {
+	style := lipgloss.NewStyle().
+		Foreground(theme.Warning).
+		Width(m.width)
+	return style.Render(fmt.Sprintf("Terminal too narrow. Widen it to at least %d columns.", minWidth))
+}
+
+// modalHeight returns the height left for a modal between the input and status bar.
+// This mirrors the layout in renderMain: the -2 accounts for the spacer above
+// the modal and chat's internal -1.
+func (m Model) modalHeight() int {
+	inputHeight := lipgloss.Height(m.chat.ViewInputOnly())
+	statusHeight := lipgloss.Height(m.statusBar.View())
+	return m.height - inputHeight - statusHeight - 2
+}
+
+// modalTop returns the screen row of the modal's top border,
+// mirroring the layout in renderMain.
+func (m Model) modalTop() int {
+	modalHeight := lipgloss.Height(m.modal.View())
+	inputHeight := lipgloss.Height(m.chat.ViewInputOnly())
+	statusHeight := lipgloss.Height(m.statusBar.View())
+	messagesHeight := m.height - modalHeight - inputHeight - statusHeight - 2
+	if messagesHeight < 0 {
+		messagesHeight = 0
+	}
+	return messagesHeight + 1 // Spacer above the modal
+}
+
 func (m Model) renderMain() string {
 	// Status bar at bottom
 	statusBar := m.statusBar.View()
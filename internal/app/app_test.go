@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pxp/hub-tui/internal/client"
+	"github.com/pxp/hub-tui/internal/config"
+)
+
+// instantAskClient streams a fixed reply without the demo client's delays.
+type instantAskClient struct {
+	*client.Mock
+	chunks []string
+}
+
+func (c *instantAskClient) Ask(ctx context.Context, message string, callbacks client.AskCallbacks) (*client.AskResponse, error) {
+	for _, chunk := range c.chunks {
+		callbacks.OnChunk(chunk)
+	}
+	return &client.AskResponse{}, nil
+}
+
+// TestFirstAskStreamsThroughCmd checks that chunks of the very first
+// message, sent before anything else has run, arrive through the returned
+// Cmd rather than depending on a program reference.
+func TestFirstAskStreamsThroughCmd(t *testing.T) {
+	m := NewDemo(config.NewInMemory())
+	fake := &instantAskClient{Mock: client.NewMock(), chunks: []string{"Hello", ", ", "world"}}
+	m.client = fake
+
+	var got strings.Builder
+	done := false
+	for cmd := m.doAsk("hi"); cmd != nil && !done; {
+		ev, ok := cmd().(streamEventMsg)
+		if !ok {
+			break
+		}
+		switch msg := ev.Msg.(type) {
+		case StreamChunkMsg:
+			got.WriteString(msg.Content)
+		case StreamDoneMsg:
+			if msg.Error != nil {
+				t.Fatalf("stream failed: %v", msg.Error)
+			}
+			done = true
+		}
+		cmd = waitForStream(ev.stream)
+	}
+
+	if !done {
+		t.Error("stream ended without a StreamDoneMsg")
+	}
+	if got.String() != "Hello, world" {
+		t.Errorf("streamed %q, want %q", got.String(), "Hello, world")
+	}
+}
+
+func TestRegenerateAfterFailedStream(t *testing.T) {
+	m := resize(mainModel(t), 80, 24)
+	m.lastPrompt = "Rotate them now"
+	m.chat.AddUserMessage(m.lastPrompt)
+	m.chat.AddHubMessage()
+	count := m.chat.MessageCount()
+
+	updated, _ := m.Update(StreamChunkMsg{Content: "Starting"})
+	updated, _ = updated.(Model).Update(StreamDoneMsg{Error: errors.New("connection reset")})
+	m = updated.(Model)
+	if got := m.chat.MessageCount(); got != count {
+		t.Errorf("failed stream left %d messages, want %d", got, count)
+	}
+	if view := m.chat.View(); !strings.Contains(view, "connection reset") {
+		t.Errorf("error not shown:\n%s", view)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = updated.(Model)
+	if cmd == nil || !m.chat.IsStreaming() {
+		t.Fatal("Ctrl+R didn't regenerate the failed reply")
+	}
+	if got := m.chat.MessageCount(); got != count {
+		t.Errorf("regenerating left %d messages, want %d", got, count)
+	}
+	if view := m.chat.View(); strings.Contains(view, "connection reset") {
+		t.Errorf("failed reply still shown after regenerating:\n%s", view)
+	}
+}
+
+func TestRegenerateReplacesRouteNote(t *testing.T) {
+	m := resize(mainModel(t), 80, 24)
+	m.lastPrompt = "Rotate them now"
+	route := RouteMsg{Type: "workflow", Target: "rotate", Ask: true}
+
+	m.chat.AddUserMessage(m.lastPrompt)
+	m.chat.AddHubMessage()
+	updated, _ := m.Update(route)
+	updated, _ = updated.(Model).Update(StreamChunkMsg{Content: "Rotated."})
+	updated, _ = updated.(Model).Update(StreamDoneMsg{})
+	count := updated.(Model).chat.MessageCount()
+
+	// Regenerating routes the prompt again
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	updated, _ = updated.(Model).Update(route)
+	m = updated.(Model)
+	if got := m.chat.MessageCount(); got != count {
+		t.Errorf("regenerating left %d messages, want %d", got, count)
+	}
+	if n := strings.Count(m.chat.View(), "routed to"); n != 1 {
+		t.Errorf("route note shown %d times, want 1", n)
+	}
+}
+
+func TestRefreshAgeTicks(t *testing.T) {
+	m := mainModel(t)
+	updated, _ := m.Update(CacheRefreshMsg{Success: true})
+	m = updated.(Model)
+	if !m.refreshAgeTicking {
+		t.Fatal("cache refresh didn't start the age tick")
+	}
+
+	// A second refresh doesn't start another tick
+	if cmd := m.startRefreshAgeTick(); cmd != nil {
+		t.Error("started a second age tick")
+	}
+
+	// The age is redrawn as it grows
+	m.cache.LastUpdate = time.Now().Add(-5 * time.Minute)
+	m.statusBar.SetLastRefresh(m.cache.LastUpdate)
+	updated, cmd := m.Update(RefreshAgeTickMsg{})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Error("age tick wasn't rescheduled")
+	}
+	if view := m.statusBar.View(); !strings.Contains(view, "updated 5m ago") {
+		t.Errorf("status bar doesn't show the cache age:\n%s", view)
+	}
+
+	// Without a cache the tick stops
+	m.cache.LastUpdate = time.Time{}
+	updated, cmd = m.Update(RefreshAgeTickMsg{})
+	if cmd != nil || updated.(Model).refreshAgeTicking {
+		t.Error("age tick kept running without a cache")
+	}
+}
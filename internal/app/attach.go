@@ -0,0 +1,91 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxAttachmentSize caps the size of a file attached with /attach.
+const maxAttachmentSize = 64 * 1024
+
+// attachment is a file whose contents are sent with the next message.
+type attachment struct {
+	name    string // Base name shown in the input and message
+	content string
+}
+
+// readAttachment reads a text file for /attach. A leading ~ expands to
+// the home directory.
+func readAttachment(path string) (*attachment, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, pathError(err)
+	}
+	if info.IsDir() {
+		return nil, errors.New("is a directory")
+	}
+	if info.Size() > maxAttachmentSize {
+		return nil, fmt.Errorf("file is %s; the limit is %s", formatSize(info.Size()), formatSize(maxAttachmentSize))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, pathError(err)
+	}
+	if !utf8.Valid(data) {
+		return nil, errors.New("not a text file")
+	}
+	return &attachment{name: filepath.Base(path), content: string(data)}, nil
+}
+
+// pathError drops the operation and path from a file error, which the
+// caller already reports, leaving e.g. "no such file or directory".
+func pathError(err error) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		return pe.Err
+	}
+	return err
+}
+
+// label describes the attachment for display, e.g. "notes.md (1.2 KB)".
+func (a *attachment) label() string {
+	return fmt.Sprintf("%s (%s)", a.name, formatSize(int64(len(a.content))))
+}
+
+// appendTo adds the attachment to message as a fenced block headed by the
+// filename. The fence is longer than any backtick run in the file so the
+// contents can't close it early.
+func (a *attachment) appendTo(message string) string {
+	fence := "```"
+	for strings.Contains(a.content, fence) {
+		fence += "`"
+	}
+	content := strings.TrimSuffix(a.content, "\n")
+	block := a.name + ":\n" + fence + "\n" + content + "\n" + fence
+	if message == "" {
+		return block
+	}
+	return message + "\n\n" + block
+}
+
+// formatSize formats a byte count for display, e.g. "512 B" or "1.2 KB".
+func formatSize(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
@@ -9,20 +9,31 @@ import (
 // Custom message types for the hub-tui application.
 // Additional messages will be added as features are implemented.
 
-// SetProgramMsg is sent to set the tea.Program reference for streaming.
-type SetProgramMsg struct {
-	Program *tea.Program
+// streamEventMsg carries one message from a streaming request.
+// The stream is re-read after each event until it is closed.
+type streamEventMsg struct {
+	Msg    tea.Msg
+	stream <-chan tea.Msg
 }
 
+// StatusTickMsg advances the status bar's connecting animation.
+type StatusTickMsg struct {
+	Frame int
+}
+
+// RefreshAgeTickMsg redraws the age of the cache in the status bar.
+type RefreshAgeTickMsg struct{}
+
 // QuitHintExpiredMsg is sent when the Ctrl+C hint timer expires.
 type QuitHintExpiredMsg struct{}
 
 // LoginResultMsg is sent when a login attempt completes.
 type LoginResultMsg struct {
-	Success   bool
-	Token     string
-	ExpiresAt string
-	Error     string
+	Success    bool
+	Token      string
+	ExpiresAt  string
+	Unverified bool // The server couldn't verify the API token
+	Error      string
 }
 
 // HealthCheckMsg is sent when a health check completes.
@@ -31,6 +42,13 @@ type HealthCheckMsg struct {
 	Error   string
 }
 
+// ModelCommandMsg is sent when a /model command completes.
+type ModelCommandMsg struct {
+	Message string // Text to show as a system message
+	Profile string // New default profile, set only when it changed
+	Error   error
+}
+
 // StreamChunkMsg is sent when a chunk of streaming response arrives.
 type StreamChunkMsg struct {
 	Content string
@@ -38,6 +56,7 @@ type StreamChunkMsg struct {
 
 // StreamDoneMsg is sent when streaming is complete.
 type StreamDoneMsg struct {
+	RunID string // Workflow run the reply came from, if any
 	Error error
 }
 
@@ -45,6 +64,7 @@ type StreamDoneMsg struct {
 type RouteMsg struct {
 	Type   string // "assistant", "workflow", "module", etc.
 	Target string // Name of the target
+	Ask    bool   // Sent by /ask routing rather than a context restore
 }
 
 // CacheRefreshMsg is sent when cache refresh completes.
@@ -54,6 +74,9 @@ type CacheRefreshMsg struct {
 	Assistants []string
 	Workflows  []string
 	Modules    []string
+
+	ActiveProfile string   // Default LLM profile ("" if none or unavailable)
+	Profiles      []string // LLM profile names (nil if unavailable)
 }
 
 // AuthExpiredMsg is sent when an API call fails due to expired/invalid token.
@@ -63,6 +86,7 @@ type AuthExpiredMsg struct{}
 type WorkflowStartedMsg struct {
 	Name  string
 	RunID string
+	Rerun bool // Started with /rerun or from the tasks modal
 }
 
 // WorkflowErrorMsg is sent when a workflow fails to start.
@@ -121,10 +145,12 @@ type RunResult struct {
 
 // StepResult mirrors client.StepResult.
 type StepResult struct {
-	StepName string
-	Success  bool
-	Output   interface{}
-	Error    string
+	StepName  string
+	Success   bool
+	Output    interface{}
+	Error     string
+	StartedAt string
+	EndedAt   string
 }
 
 // AskNeedsInputMsg indicates the API needs more input (parameter collection).
@@ -137,6 +163,15 @@ type AskNeedsInputMsg struct {
 type AskExecutedMsg struct {
 	Target string
 	Result *client.ExecuteResult
+	RunID  string // Workflow run that executed, if any
+}
+
+// ReplyRunLoadedMsg is sent when the workflow run behind a chat reply has
+// been fetched, so its steps can be shown under the reply.
+type ReplyRunLoadedMsg struct {
+	MsgIdx int // Index of the reply in the transcript
+	Run    *client.Run
+	Error  error
 }
 
 // AskErrorMsg indicates an API error.
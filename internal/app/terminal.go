@@ -0,0 +1,57 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Terminal is the program's output. Bubble Tea writes each frame in a
+// single Write, so serializing writes lets escape sequences sent outside
+// the renderer, like notifications, go out between frames instead of
+// tearing one.
+type Terminal struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewTerminal wraps f, usually os.Stdout. Pass it to tea.WithOutput and
+// Model.SetTerminal.
+func NewTerminal(f *os.File) *Terminal {
+	return &Terminal{f: f}
+}
+
+// Write writes p in one piece, never interleaved with another Write.
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.f.Write(p)
+}
+
+// Read, Close and Fd let Bubble Tea treat the terminal as a TTY.
+func (t *Terminal) Read(p []byte) (int, error) { return t.f.Read(p) }
+func (t *Terminal) Close() error               { return t.f.Close() }
+func (t *Terminal) Fd() uintptr                { return t.f.Fd() }
+
+// Limits on server-supplied text put into a notification.
+const (
+	maxNotifyNameLen = 40
+	maxNotifyTextLen = 200
+)
+
+// notifySafe strips control characters from s so it can't end the escape
+// sequence it's sent in or start another, and caps it at max runes.
+func notifySafe(s string, max int) string {
+	s = strings.Map(func(r rune) rune {
+		// C0 controls, DEL and C1 controls (including CSI, 0x9b)
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, s)
+	if utf8.RuneCountInString(s) > max {
+		s = string([]rune(s)[:max-1]) + "…"
+	}
+	return s
+}
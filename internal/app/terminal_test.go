@@ -0,0 +1,27 @@
+package app
+
+import "testing"
+
+func TestNotifySafe(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"plain", "daily-digest", 40, "daily-digest"},
+		{"osc injection", "a\x1b]0;title\x07b", 40, "a]0;titleb"},
+		{"string terminator", "a\x1b\\b", 40, "a\\b"},
+		{"c1 csi", "a\u009b2Jb", 40, "a2Jb"},
+		{"del and newline", "a\x7f\nb", 40, "ab"},
+		{"capped", "abcdefghij", 5, "abcd…"},
+		{"capped multibyte", "日本語のワークフロー", 4, "日本語…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notifySafe(tt.in, tt.max); got != tt.want {
+				t.Errorf("notifySafe(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pxp/hub-tui/internal/config"
+	"github.com/pxp/hub-tui/internal/ui/modal"
+)
+
+// mainModel returns a demo model past login with a short conversation.
+func mainModel(t *testing.T) Model {
+	t.Helper()
+	m := NewDemo(config.NewInMemory())
+	m.state = StateMain
+	m.chat.AddUserMessage("How do I rotate the logs?")
+	m.chat.AddHubMessage()
+	m.chat.AppendToLastMessage("Run the **rotate** workflow:\n\n```sh\nhub run rotate\n```")
+	m.chat.FinishLastMessage()
+	return m
+}
+
+func resize(m Model, width, height int) Model {
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return updated.(Model)
+}
+
+func TestViewTinySizes(t *testing.T) {
+	overlays := map[string]func(m *Model){
+		"chat": func(m *Model) {},
+		"help modal": func(m *Model) {
+			m.modal.Open(modal.NewHelpModal(m.keys))
+		},
+		"tasks modal": func(m *Model) {
+			m.modal.Open(m.newTasksModal())
+		},
+		"login": func(m *Model) {
+			m.state = StateLogin
+			m.login = m.newLogin(true, "")
+		},
+	}
+	sizes := [][2]int{{0, 0}, {0, 24}, {1, 1}, {5, 3}, {minWidth - 1, 10}, {minWidth, 0}, {minWidth, 2}, {minWidth, 24}}
+
+	for name, open := range overlays {
+		for _, size := range sizes {
+			width, height := size[0], size[1]
+			t.Run(fmt.Sprintf("%s %dx%d", name, width, height), func(t *testing.T) {
+				m := mainModel(t)
+				open(&m)
+				m = resize(m, width, height)
+
+				view := m.View() // Must not panic
+
+				tooNarrow := view == m.renderTooNarrow()
+				wantNotice := width > 0 && width < minWidth
+				if tooNarrow != wantNotice {
+					t.Errorf("too narrow notice shown = %v, want %v", tooNarrow, wantNotice)
+				}
+			})
+		}
+	}
+}
+
+func TestViewNarrowNotice(t *testing.T) {
+	m := resize(mainModel(t), 20, 10)
+	view := m.View()
+	if !strings.Contains(view, fmt.Sprintf("%d columns", minWidth)) {
+		t.Errorf("notice %q doesn't name the minimum width", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 20 {
+			t.Errorf("notice line %q is wider than the terminal", line)
+		}
+	}
+
+	// Widening again restores the layout
+	m = resize(m, 80, 24)
+	if strings.Contains(m.View(), "Terminal too narrow") {
+		t.Error("notice still shown at 80 columns")
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 )
@@ -32,6 +34,7 @@ type AskResponse struct {
 	Schema *ParamSchema   `json:"schema,omitempty"`
 	Result *ExecuteResult `json:"result,omitempty"`
 	Error  *AskError      `json:"error,omitempty"`
+	RunID  string         `json:"run_id,omitempty"` // Set when a workflow ran
 
 	// Legacy fields for backward compatibility with streaming responses
 	Success bool   `json:"success"`
@@ -53,6 +56,7 @@ type ParamField struct {
 	Description string      `json:"description"`
 	Default     interface{} `json:"default,omitempty"`
 	Value       interface{} `json:"value,omitempty"`
+	Enum        []string    `json:"enum,omitempty"` // Allowed values, if constrained
 	Error       string      `json:"error,omitempty"`
 }
 
@@ -128,13 +132,32 @@ func (c *Client) Ask(ctx context.Context, message string, callbacks AskCallbacks
 	return &apiResp, nil
 }
 
+// maxSSELineSize is the longest SSE line the stream readers accept.
+// Large tool-call payloads and non-streaming done messages arrive on one line.
+const maxSSELineSize = 1024 * 1024
+
+// newSSEScanner returns a line scanner sized for SSE streams.
+func newSSEScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+	return scanner
+}
+
+// sseReadError turns a scanner error into a clear stream error.
+func sseReadError(err error) error {
+	if errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("stream event exceeds %d bytes: %w", maxSSELineSize, err)
+	}
+	return err
+}
+
 // readSSEStream reads a Server-Sent Events stream with typed events.
 func (c *Client) readSSEStream(ctx context.Context, resp *http.Response, callbacks AskCallbacks) (*AskResponse, error) {
 	var fullContent strings.Builder
 	var currentEvent string
 	var result AskResponse
 
-	scanner := bufio.NewScanner(resp.Body)
+	scanner := newSSEScanner(resp.Body)
 	for scanner.Scan() {
 		select {
 		case <-ctx.Done():
@@ -192,6 +215,7 @@ func (c *Client) readSSEStream(ctx context.Context, resp *http.Response, callbac
 					result.Status = resp.Status
 					result.Target = resp.Target
 					result.Result = resp.Result
+					result.RunID = resp.RunID
 					if resp.Result != nil {
 						result.Message = resp.Result.Message
 						result.Success = resp.Result.Success
@@ -199,12 +223,15 @@ func (c *Client) readSSEStream(ctx context.Context, resp *http.Response, callbac
 				}
 
 			case "error":
-				// Operation failed
+				// Operation failed; structured errors carry an error object,
+				// anything else is a stream failure with a plain message
 				var resp AskResponse
-				if err := json.Unmarshal([]byte(data), &resp); err == nil {
+				if err := json.Unmarshal([]byte(data), &resp); err == nil && resp.Error != nil {
 					result.Status = resp.Status
 					result.Target = resp.Target
 					result.Error = resp.Error
+				} else {
+					return &AskResponse{Message: fullContent.String()}, parseStreamError(data)
 				}
 
 			case "done":
@@ -219,6 +246,9 @@ func (c *Client) readSSEStream(ctx context.Context, resp *http.Response, callbac
 					result.Error = done.Error
 					result.Success = done.Success
 					result.Message = done.Message
+					if done.RunID != "" {
+						result.RunID = done.RunID
+					}
 
 					// For status-based responses, populate legacy fields
 					if done.Status == StatusExecuted && done.Result != nil {
@@ -244,7 +274,7 @@ func (c *Client) readSSEStream(ctx context.Context, resp *http.Response, callbac
 	}
 
 	if err := scanner.Err(); err != nil {
-		return &AskResponse{Message: fullContent.String()}, err
+		return &AskResponse{Message: fullContent.String()}, sseReadError(err)
 	}
 
 	// Use accumulated content if message not set
@@ -1,7 +1,6 @@
 package client
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -97,7 +96,7 @@ func (c *Client) readAssistantChatStream(ctx context.Context, resp *http.Respons
 	var currentEvent string
 	var result AskResponse
 
-	scanner := bufio.NewScanner(resp.Body)
+	scanner := newSSEScanner(resp.Body)
 	for scanner.Scan() {
 		select {
 		case <-ctx.Done():
@@ -139,6 +138,9 @@ func (c *Client) readAssistantChatStream(ctx context.Context, resp *http.Respons
 					}
 				}
 
+			case "error":
+				return &AskResponse{Message: fullContent.String()}, parseStreamError(data)
+
 			case "done":
 				var done struct {
 					Success bool   `json:"success"`
@@ -155,7 +157,7 @@ func (c *Client) readAssistantChatStream(ctx context.Context, resp *http.Respons
 	}
 
 	if err := scanner.Err(); err != nil {
-		return &AskResponse{Message: fullContent.String()}, err
+		return &AskResponse{Message: fullContent.String()}, sseReadError(err)
 	}
 
 	if result.Message == "" {
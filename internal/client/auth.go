@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -57,36 +58,109 @@ func (c *Client) Login(username, password string) (*LoginResponse, error) {
 	return &loginResp, nil
 }
 
-// TokenExpiry extracts the expiry time from a JWT token.
-// Returns zero time if the token is invalid or has no expiry.
-func TokenExpiry(token string) time.Time {
+// ErrTokenUnverified is returned by VerifyToken when the server can't
+// verify tokens and the token carries no expiry to check instead.
+var ErrTokenUnverified = errors.New("server cannot verify tokens")
+
+// VerifyToken checks that the client's token is accepted by hub-core.
+// Servers without /auth/verify can't say, so a JWT is checked against its
+// expiry instead and any other token gets ErrTokenUnverified.
+func (c *Client) VerifyToken() error {
+	resp, err := c.get("/auth/verify")
+	if err != nil {
+		return fmt.Errorf("cannot connect to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return verifyTokenExpiry(c.token)
+	case http.StatusUnauthorized:
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    "invalid or expired token",
+		}
+	default:
+		return parseError(resp)
+	}
+}
+
+// verifyTokenExpiry checks a token locally, for servers that can't verify it.
+func verifyTokenExpiry(token string) error {
+	if TokenExpiry(token).IsZero() {
+		return ErrTokenUnverified
+	}
+	if IsTokenExpired(token) {
+		return &APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    "invalid or expired token",
+		}
+	}
+	return nil
+}
+
+// Claims holds the common claims of a JWT payload.
+// Missing claims are left at their zero value.
+type Claims struct {
+	Subject   string `json:"sub"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Issuer    string `json:"iss"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// User returns the most specific user identifier in the claims.
+func (c *Claims) User() string {
+	switch {
+	case c.Username != "":
+		return c.Username
+	case c.Name != "":
+		return c.Name
+	case c.Email != "":
+		return c.Email
+	default:
+		return c.Subject
+	}
+}
+
+// TokenClaims decodes the payload of a JWT token.
+// Returns nil if the token is not a JWT (e.g. an opaque API token).
+func TokenClaims(token string) *Claims {
 	parts := strings.Split(token, ".")
 	if len(parts) != 3 {
-		return time.Time{}
+		return nil
 	}
 
 	// Decode the payload (second part)
 	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
 	if err != nil {
-		return time.Time{}
+		return nil
 	}
 
-	var claims struct {
-		Exp int64 `json:"exp"`
-	}
+	var claims Claims
 	if json.Unmarshal(payload, &claims) != nil {
-		return time.Time{}
+		return nil
 	}
+	return &claims
+}
 
-	if claims.Exp == 0 {
+// TokenExpiry extracts the expiry time from a JWT token.
+// Returns zero time if the token is invalid or has no expiry.
+func TokenExpiry(token string) time.Time {
+	claims := TokenClaims(token)
+	if claims == nil || claims.ExpiresAt == 0 {
 		return time.Time{}
 	}
-
-	return time.Unix(claims.Exp, 0)
+	return time.Unix(claims.ExpiresAt, 0)
 }
 
 // IsTokenExpired checks if a token is expired.
-// Returns true if the token is invalid or expired.
+// Returns true if the token is empty or expired. Opaque (non-JWT) tokens
+// have no readable expiry and are treated as valid.
 func IsTokenExpired(token string) bool {
 	if token == "" {
 		return true
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT expiring at exp.
+func testJWT(exp time.Time) string {
+	payload := fmt.Sprintf(`{"sub":"emily","exp":%d}`, exp.Unix())
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestVerifyToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		token   string
+		wantErr func(error) bool
+	}{
+		{"verified", http.StatusOK, "opaque", nil},
+		{"rejected", http.StatusUnauthorized, "opaque", IsAuthError},
+		{"no endpoint, valid jwt", http.StatusNotFound, testJWT(time.Now().Add(time.Hour)), nil},
+		{"no endpoint, expired jwt", http.StatusNotFound, testJWT(time.Now().Add(-time.Hour)), IsAuthError},
+		{"no endpoint, opaque token", http.StatusNotFound, "opaque", func(err error) bool {
+			return errors.Is(err, ErrTokenUnverified)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fakeClient(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path != "/auth/verify" {
+					t.Errorf("requested %s, want only /auth/verify", req.URL.Path)
+				}
+				return jsonResponse(tt.status, `{}`), nil
+			})
+			c.SetToken(tt.token)
+
+			err := c.VerifyToken()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("VerifyToken: %v", err)
+				}
+				return
+			}
+			if err == nil || !tt.wantErr(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
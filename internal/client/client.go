@@ -2,9 +2,12 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -23,6 +26,31 @@ func New(baseURL string) *Client {
 	}
 }
 
+// NormalizeServerURL validates a user-entered server URL, prepending
+// "http://" when no scheme is given and dropping any trailing slash.
+func NormalizeServerURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("server URL is required")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL: %s", raw)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("server URL must use http or https: %s", raw)
+	}
+	// A mistyped scheme like "http//host" parses as host "http"
+	if u.Hostname() == "" || strings.HasPrefix(u.Path, "//") {
+		return "", fmt.Errorf("server URL has no host: %s", raw)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
 // SetToken sets the auth token for requests.
 func (c *Client) SetToken(token string) {
 	c.token = token
@@ -102,6 +130,43 @@ func (c *Client) Health() error {
 	return nil
 }
 
+// ServerInfo describes the hub-core server build.
+type ServerInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"build_date,omitempty"`
+}
+
+// ServerInfo fetches the server version from /version, falling back to the
+// /health response for servers without that endpoint. An empty Version
+// means the server doesn't report one.
+func (c *Client) ServerInfo() (*ServerInfo, error) {
+	resp, err := c.get("/version")
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp, err = c.get("/health")
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to server: %w", err)
+		}
+		defer resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseError(resp)
+	}
+
+	var info ServerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		// A plain-text health response carries no version
+		return &ServerInfo{}, nil
+	}
+	return &info, nil
+}
+
 // APIError represents an error response from the API.
 type APIError struct {
 	StatusCode int
@@ -112,12 +177,55 @@ func (e *APIError) Error() string {
 	return e.Message
 }
 
+// StreamError is reported by the server through an SSE error event mid-stream.
+type StreamError struct {
+	Message string
+}
+
+func (e *StreamError) Error() string {
+	return e.Message
+}
+
+// parseStreamError extracts the message from an SSE error event payload.
+func parseStreamError(data string) *StreamError {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil || payload.Message == "" {
+		return &StreamError{Message: "stream failed"}
+	}
+	return &StreamError{Message: payload.Message}
+}
+
 // IsAuthError returns true if the error is an authentication error (401).
 func IsAuthError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.StatusCode == http.StatusUnauthorized
+	return statusCode(err) == http.StatusUnauthorized
+}
+
+// IsNotFound returns true if the server reported the resource missing (404).
+func IsNotFound(err error) bool {
+	return statusCode(err) == http.StatusNotFound
+}
+
+// IsConflict returns true if the request conflicts with existing state (409),
+// e.g. a name that is already taken.
+func IsConflict(err error) bool {
+	return statusCode(err) == http.StatusConflict
+}
+
+// IsServerError returns true if the server failed to handle the request (5xx).
+func IsServerError(err error) bool {
+	code := statusCode(err)
+	return code >= 500 && code < 600
+}
+
+// statusCode returns the HTTP status of an APIError in err's chain, or 0.
+func statusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
 	}
-	return false
+	return 0
 }
 
 // parseError extracts an error message from an error response.
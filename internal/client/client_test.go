@@ -0,0 +1,41 @@
+package client
+
+import "testing"
+
+func TestNormalizeServerURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"complete", "http://hub.local:8787", "http://hub.local:8787", false},
+		{"https", "https://hub.example.com", "https://hub.example.com", false},
+		{"missing scheme", "hub.local:8787", "http://hub.local:8787", false},
+		{"trailing slash", "http://hub.local:8787/", "http://hub.local:8787", false},
+		{"bare host and port", "192.168.1.100:8787", "http://192.168.1.100:8787", false},
+		{"surrounding spaces", "  hub.local  ", "http://hub.local", false},
+		{"mistyped scheme", "htp://hub.local:8787", "", true},
+		{"missing colon", "http//hub.local:8787", "", true},
+		{"blank host", "http://", "", true},
+		{"blank host with port", "http://:8787", "", true},
+		{"empty", "   ", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeServerURL(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NormalizeServerURL(%q) = %q, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeServerURL(%q): %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeServerURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
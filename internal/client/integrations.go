@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 )
 
 // Integration represents an integration from hub-core.
@@ -76,6 +77,44 @@ func (c *Client) ConfigureIntegration(name, profile string, config map[string]st
 	return nil
 }
 
+// SetIntegrationDefaultProfile sets the profile an integration uses by default.
+func (c *Client) SetIntegrationDefaultProfile(name, profile string) error {
+	req := struct {
+		Profile string `json:"profile"`
+	}{
+		Profile: profile,
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	resp, err := c.put("/integrations/"+name+"/default-profile", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("cannot connect to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return parseError(resp)
+	}
+	return nil
+}
+
+// DeleteIntegrationProfile deletes a configured integration profile.
+func (c *Client) DeleteIntegrationProfile(name, profile string) error {
+	resp, err := c.delete("/integrations/" + name + "/profiles/" + profile)
+	if err != nil {
+		return fmt.Errorf("cannot connect to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return parseError(resp)
+	}
+	return nil
+}
+
 // TestIntegration tests an integration.
 func (c *Client) TestIntegration(name string) error {
 	resp, err := c.post("/integrations/"+name+"/test", nil)
@@ -90,6 +129,77 @@ func (c *Client) TestIntegration(name string) error {
 	return nil
 }
 
+// ConfigValidation is the result of validating an integration config.
+type ConfigValidation struct {
+	Valid  bool              `json:"valid"`
+	Errors map[string]string `json:"errors,omitempty"` // Field key -> error message
+}
+
+// ValidateIntegrationConfig checks an integration config without saving it.
+func (c *Client) ValidateIntegrationConfig(name string, config map[string]string) (*ConfigValidation, error) {
+	body, err := json.Marshal(struct {
+		Config map[string]string `json:"config"`
+	}{Config: config})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	resp, err := c.post("/integrations/"+name+"/validate", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, parseError(resp)
+	}
+
+	var result ConfigValidation
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response from server: %w", err)
+	}
+	return &result, nil
+}
+
+// IntegrationTestResult is the result of testing an integration profile.
+type IntegrationTestResult struct {
+	Success   bool   `json:"success"`
+	LatencyMs int    `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// TestIntegrationProfile tests an integration profile. If config is non-nil
+// it is tested in place of the profile's saved configuration, so settings
+// can be checked before they are saved.
+func (c *Client) TestIntegrationProfile(name, profile string, config map[string]string) (*IntegrationTestResult, error) {
+	var body io.Reader
+	if config != nil {
+		data, err := json.Marshal(struct {
+			Config map[string]string `json:"config"`
+		}{Config: config})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode config: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	resp, err := c.post("/integrations/"+name+"/profiles/"+profile+"/test", body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, parseError(resp)
+	}
+
+	var result IntegrationTestResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response from server: %w", err)
+	}
+	return &result, nil
+}
+
 // ModelInfo represents information about an available model.
 type ModelInfo struct {
 	ID            string `json:"id"`
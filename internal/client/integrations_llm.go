@@ -226,6 +226,26 @@ func (c *Client) CreateLLMProfile(integration string, req CreateProfileRequest)
 	return nil
 }
 
+// UpdateLLMProfile replaces the settings of an existing LLM profile.
+// The profile keeps its name; rename by creating a new profile instead.
+func (c *Client) UpdateLLMProfile(integration, name string, req CreateProfileRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	resp, err := c.put("/integrations/"+integration+"/profiles/"+name, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("cannot connect to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return parseError(resp)
+	}
+	return nil
+}
+
 // DeleteLLMProfile deletes an LLM profile.
 func (c *Client) DeleteLLMProfile(integration, profile string) error {
 	resp, err := c.delete("/integrations/" + integration + "/profiles/" + profile)
@@ -323,3 +343,9 @@ func (c *Client) SetDefaultLLMProfile(integration, profile string) error {
 	}
 	return nil
 }
+
+// ClearDefaultLLMProfile removes the default LLM profile for an integration.
+// An empty profile name tells hub-core to unset the default.
+func (c *Client) ClearDefaultLLMProfile(integration string) error {
+	return c.SetDefaultLLMProfile(integration, "")
+}
@@ -0,0 +1,64 @@
+package client
+
+import "context"
+
+// Interface is the hub-core API used by the app and its modals.
+// Client talks to a real server; Mock serves canned data for demos.
+type Interface interface {
+	// Connection and auth
+	SetToken(token string)
+	Token() string
+	BaseURL() string
+	SetBaseURL(url string)
+	Health() error
+	ServerInfo() (*ServerInfo, error)
+	Login(username, password string) (*LoginResponse, error)
+	VerifyToken() error
+
+	// Chat
+	Ask(ctx context.Context, message string, callbacks AskCallbacks) (*AskResponse, error)
+	AskDirect(req AskRequest) (*AskResponse, error)
+	ListAssistants() ([]Assistant, error)
+	AssistantChat(ctx context.Context, assistant, message string, callbacks AssistantChatCallbacks) (*AskResponse, error)
+
+	// Modules and workflows
+	ListModules() ([]Module, error)
+	EnableModule(name string) error
+	DisableModule(name string) error
+	ListWorkflows() ([]Workflow, error)
+	RunWorkflow(name string) (string, error)
+
+	// Runs
+	ListRuns(filter *RunsFilter) (*RunsResponse, error)
+	GetRun(id string) (*Run, error)
+	GetRunWithRetry(id string, retry RunRetry) (*Run, error)
+	CancelRun(id string) error
+	DismissRun(id string) error
+
+	// Integrations
+	ListIntegrations() ([]Integration, error)
+	ConfigureIntegration(name, profile string, config map[string]string) error
+	SetIntegrationDefaultProfile(name, profile string) error
+	DeleteIntegrationProfile(name, profile string) error
+	TestIntegration(name string) error
+	ValidateIntegrationConfig(name string, config map[string]string) (*ConfigValidation, error)
+	TestIntegrationProfile(name, profile string, config map[string]string) (*IntegrationTestResult, error)
+	ListIntegrationModels(name string, limit int, cursor string) (*ModelsResult, error)
+
+	// LLM integrations
+	ListLLMProviders(integration string) ([]ProviderAccount, error)
+	ListAvailableLLMProviders(integration string) ([]AvailableProvider, error)
+	GetLLMProviderFields(integration, provider string) ([]ProviderFieldInfo, error)
+	AddLLMProvider(integration string, req AddProviderRequest) error
+	DeleteLLMProvider(integration, provider, account string) error
+	ListLLMProfiles(integration string) (*LLMProfileList, error)
+	CreateLLMProfile(integration string, req CreateProfileRequest) error
+	UpdateLLMProfile(integration, name string, req CreateProfileRequest) error
+	DeleteLLMProfile(integration, profile string) error
+	TestLLMProfile(integration, profile string) (*LLMTestResult, error)
+	ListLLMModels(integration, provider string, limit int, cursor string) (*LLMModelsResult, error)
+	SetDefaultLLMProfile(integration, profile string) error
+	ClearDefaultLLMProfile(integration string) error
+}
+
+var _ Interface = (*Client)(nil)
@@ -0,0 +1,577 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockBaseURL is the server URL reported by the mock client.
+const MockBaseURL = "demo"
+
+// mockChunkDelay paces the scripted reply so it streams like a real one.
+const mockChunkDelay = 40 * time.Millisecond
+
+// mockRunDuration is how long a workflow started on the mock stays running.
+const mockRunDuration = 5 * time.Second
+
+// Mock implements Interface with canned data, for demos and development
+// without a running hub-core. Changes made through it last until exit.
+type Mock struct {
+	mu sync.Mutex
+
+	token      string
+	modules    []Module
+	runs       []Run
+	nextRunID  int
+	finishes   map[string]time.Time // Run ID -> when a started run completes
+	apiProfile map[string][]string  // Integration name -> profile names
+	apiDefault map[string]string    // Integration name -> default profile
+	providers  []ProviderAccount
+	profiles   []LLMProfile
+}
+
+var _ Interface = (*Mock)(nil)
+
+// NewMock creates a mock client with sample assistants, workflows, modules,
+// runs and integrations.
+func NewMock() *Mock {
+	now := time.Now()
+	return &Mock{
+		modules: []Module{
+			{Name: "weather", Description: "Local forecasts", Enabled: true, Version: "1.2.0"},
+			{Name: "calendar", Description: "Events and reminders", Enabled: true, Version: "0.9.1"},
+			{Name: "finance", Description: "Budget tracking", Enabled: false, Version: "0.3.0"},
+		},
+		runs: []Run{
+			{ID: "run-4", Workflow: "sync_notes", Status: "running", StartedAt: now.Add(-2 * time.Minute)},
+			{ID: "run-3", Workflow: "morning_briefing", Status: "completed", StartedAt: now.Add(-3 * time.Hour), EndedAt: now.Add(-3*time.Hour + 40*time.Second)},
+			{ID: "run-2", Workflow: "backup_photos", Status: "failed", StartedAt: now.Add(-5 * time.Hour), EndedAt: now.Add(-5*time.Hour + 12*time.Second), Error: "storage quota exceeded"},
+			{ID: "run-1", Workflow: "weekly_review", Status: "failed", StartedAt: now.Add(-26 * time.Hour), EndedAt: now.Add(-26*time.Hour + 3*time.Second), Error: "calendar token expired", NeedsAttention: true},
+		},
+		nextRunID:  5,
+		finishes:   make(map[string]time.Time),
+		apiProfile: map[string][]string{"github": {"default", "work"}},
+		apiDefault: map[string]string{"github": "default"},
+		providers: []ProviderAccount{
+			{Provider: "anthropic", DisplayName: "Anthropic", Accounts: []string{"default"}},
+			{Provider: "openai", DisplayName: "OpenAI", Accounts: []string{"default"}},
+		},
+		profiles: []LLMProfile{
+			{Name: "fast", Provider: "anthropic", Account: "default", Model: "claude-haiku", IsDefault: true},
+			{Name: "smart", Provider: "anthropic", Account: "default", Model: "claude-sonnet"},
+			{Name: "backup", Provider: "openai", Account: "default", Model: "gpt-4o"},
+		},
+	}
+}
+
+// --- Connection and auth ---
+
+// SetToken sets the auth token.
+func (m *Mock) SetToken(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = token
+}
+
+// Token returns the auth token.
+func (m *Mock) Token() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.token
+}
+
+// BaseURL returns MockBaseURL.
+func (m *Mock) BaseURL() string {
+	return MockBaseURL
+}
+
+// SetBaseURL is a no-op; the mock has no server.
+func (m *Mock) SetBaseURL(url string) {}
+
+// Health always succeeds.
+func (m *Mock) Health() error {
+	return nil
+}
+
+// ServerInfo reports a demo version.
+func (m *Mock) ServerInfo() (*ServerInfo, error) {
+	return &ServerInfo{Version: "demo"}, nil
+}
+
+// Login accepts any credentials.
+func (m *Mock) Login(username, password string) (*LoginResponse, error) {
+	return &LoginResponse{Token: "demo"}, nil
+}
+
+// VerifyToken accepts any token.
+func (m *Mock) VerifyToken() error {
+	return nil
+}
+
+// --- Chat ---
+
+// Ask streams a scripted reply.
+func (m *Mock) Ask(ctx context.Context, message string, callbacks AskCallbacks) (*AskResponse, error) {
+	reply := fmt.Sprintf("This is **demo mode**, so there's no hub-core behind me. You said:\n\n> %s\n\n"+
+		"Try `/tasks`, `/modules` or `@fitness_trainer` to explore the sample data.", message)
+	return mockStream(ctx, reply, callbacks.OnChunk)
+}
+
+// AskDirect returns a scripted result.
+func (m *Mock) AskDirect(req AskRequest) (*AskResponse, error) {
+	msg := "Demo mode: nothing was executed."
+	return &AskResponse{
+		Status:  StatusExecuted,
+		Target:  req.Target,
+		Result:  &ExecuteResult{Success: true, Message: msg},
+		Success: true,
+		Message: msg,
+	}, nil
+}
+
+// ListAssistants returns the sample assistants.
+func (m *Mock) ListAssistants() ([]Assistant, error) {
+	return []Assistant{
+		{Name: "fitness_trainer", DisplayName: "Fitness Trainer", Description: "Workout plans and tracking", Enabled: true},
+		{Name: "chef", DisplayName: "Chef", Description: "Recipes and meal planning", Enabled: true},
+	}, nil
+}
+
+// AssistantChat streams a scripted reply from the named assistant.
+func (m *Mock) AssistantChat(ctx context.Context, assistant, message string, callbacks AssistantChatCallbacks) (*AskResponse, error) {
+	if callbacks.OnAssistant != nil {
+		callbacks.OnAssistant(AssistantInfo{Name: assistant, DisplayName: assistant})
+	}
+	reply := fmt.Sprintf("Hi, I'm the demo **%s**. In a real session I'd answer:\n\n> %s", assistant, message)
+	return mockStream(ctx, reply, callbacks.OnChunk)
+}
+
+// mockStream sends reply to onChunk a word at a time.
+func mockStream(ctx context.Context, reply string, onChunk func(string)) (*AskResponse, error) {
+	var sent strings.Builder
+	for _, word := range strings.SplitAfter(reply, " ") {
+		select {
+		case <-ctx.Done():
+			return &AskResponse{Message: sent.String()}, ctx.Err()
+		case <-time.After(mockChunkDelay):
+		}
+		if onChunk != nil {
+			onChunk(word)
+		}
+		sent.WriteString(word)
+	}
+	return &AskResponse{Success: true, Message: reply}, nil
+}
+
+// --- Modules and workflows ---
+
+// ListModules returns the sample modules.
+func (m *Mock) ListModules() ([]Module, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Module(nil), m.modules...), nil
+}
+
+// EnableModule enables a module.
+func (m *Mock) EnableModule(name string) error {
+	return m.setModuleEnabled(name, true)
+}
+
+// DisableModule disables a module.
+func (m *Mock) DisableModule(name string) error {
+	return m.setModuleEnabled(name, false)
+}
+
+func (m *Mock) setModuleEnabled(name string, enabled bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.modules {
+		if m.modules[i].Name == name {
+			m.modules[i].Enabled = enabled
+			return nil
+		}
+	}
+	return mockNotFound("module", name)
+}
+
+// ListWorkflows returns the sample workflows.
+func (m *Mock) ListWorkflows() ([]Workflow, error) {
+	next := time.Now().Truncate(24 * time.Hour).Add(31 * time.Hour)
+	return []Workflow{
+		{Name: "morning_briefing", Description: "Weather, calendar and news summary", Trigger: Trigger{Type: "schedule", Cron: "0 7 * * *"}, Enabled: true, NextRun: &next, Frequency: "Daily at 07:00"},
+		{Name: "backup_photos", Description: "Copy new photos to storage", Trigger: Trigger{Type: "manual"}, Enabled: true},
+		{Name: "sync_notes", Description: "Sync notes between devices", Trigger: Trigger{Type: "manual"}, Enabled: true},
+		{Name: "weekly_review", Description: "Summarise the past week", Trigger: Trigger{Type: "manual"}, Enabled: false},
+	}, nil
+}
+
+// RunWorkflow starts a run that completes after mockRunDuration.
+func (m *Mock) RunWorkflow(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := fmt.Sprintf("run-%d", m.nextRunID)
+	m.nextRunID++
+	now := time.Now()
+	m.runs = append([]Run{{ID: id, Workflow: name, Status: "running", StartedAt: now}}, m.runs...)
+	m.finishes[id] = now.Add(mockRunDuration)
+	return id, nil
+}
+
+// --- Runs ---
+
+// advanceRuns completes runs started with RunWorkflow once they've run long
+// enough. Callers must hold m.mu.
+func (m *Mock) advanceRuns() {
+	for i := range m.runs {
+		r := &m.runs[i]
+		finish, ok := m.finishes[r.ID]
+		if ok && r.Status == "running" && time.Now().After(finish) {
+			r.Status = "completed"
+			r.EndedAt = finish
+		}
+	}
+}
+
+// ListRuns returns the runs matching the filter, newest first, in one page.
+func (m *Mock) ListRuns(filter *RunsFilter) (*RunsResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advanceRuns()
+
+	var runs []Run
+	for _, r := range m.runs {
+		if filter != nil {
+			if filter.Status != "" && r.Status != filter.Status {
+				continue
+			}
+			if filter.NeedsAttention != nil && r.NeedsAttention != *filter.NeedsAttention {
+				continue
+			}
+			if filter.Since != "" && r.StartedAt.Format("2006-01-02") < filter.Since {
+				continue
+			}
+			if filter.Until != "" && r.StartedAt.Format("2006-01-02") >= filter.Until {
+				continue
+			}
+		}
+		runs = append(runs, r)
+	}
+	return &RunsResponse{
+		Runs:       runs,
+		Pagination: Pagination{Total: len(runs), Limit: len(runs)},
+	}, nil
+}
+
+// GetRun returns a run with a sample result.
+func (m *Mock) GetRun(id string) (*Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advanceRuns()
+	for _, r := range m.runs {
+		if r.ID != id {
+			continue
+		}
+		if r.Status != "running" {
+			step := StepResult{StepName: "run", Success: r.Status == "completed", Error: r.Error}
+			if !r.EndedAt.IsZero() {
+				started, ended := r.StartedAt, r.EndedAt
+				step.StartedAt, step.EndedAt = &started, &ended
+			}
+			r.Result = &RunResult{
+				WorkflowName: r.Workflow,
+				Success:      step.Success,
+				Output:       "Demo run output.",
+				Steps:        []StepResult{step},
+				Error:        r.Error,
+			}
+		}
+		return &r, nil
+	}
+	return nil, mockNotFound("run", id)
+}
+
+// GetRunWithRetry returns a run, retrying like Client.GetRunWithRetry.
+func (m *Mock) GetRunWithRetry(id string, retry RunRetry) (*Run, error) {
+	return getRunWithRetry(m.GetRun, id, retry)
+}
+
+// CancelRun cancels a running run.
+func (m *Mock) CancelRun(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.runs {
+		if m.runs[i].ID == id {
+			m.runs[i].Status = "cancelled"
+			m.runs[i].EndedAt = time.Now()
+			return nil
+		}
+	}
+	return mockNotFound("run", id)
+}
+
+// DismissRun clears a run's needs-attention flag.
+func (m *Mock) DismissRun(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.runs {
+		if m.runs[i].ID == id {
+			m.runs[i].NeedsAttention = false
+			return nil
+		}
+	}
+	return mockNotFound("run", id)
+}
+
+// --- Integrations ---
+
+// ListIntegrations returns an API key integration and an LLM integration.
+func (m *Mock) ListIntegrations() ([]Integration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	github := m.apiProfile["github"]
+	return []Integration{
+		{
+			Name: "github", DisplayName: "GitHub", Type: "api", ConfigType: "api_key",
+			Description: "Repositories and issues", Configured: len(github) > 0,
+			Profiles: append([]string(nil), github...), DefaultProfile: m.apiDefault["github"],
+			Fields: []string{"token"},
+		},
+		{
+			Name: "llm", DisplayName: "LLM", Type: "llm", ConfigType: "llm",
+			Description: "Language model providers", Configured: true,
+			ProviderCount: len(m.providers), ProfileCount: len(m.profiles),
+		},
+	}, nil
+}
+
+// ConfigureIntegration adds or updates an integration profile.
+func (m *Mock) ConfigureIntegration(name, profile string, config map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.apiProfile[name] {
+		if p == profile {
+			return nil
+		}
+	}
+	m.apiProfile[name] = append(m.apiProfile[name], profile)
+	if m.apiDefault[name] == "" {
+		m.apiDefault[name] = profile
+	}
+	return nil
+}
+
+// SetIntegrationDefaultProfile sets an integration's default profile.
+func (m *Mock) SetIntegrationDefaultProfile(name, profile string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apiDefault[name] = profile
+	return nil
+}
+
+// DeleteIntegrationProfile deletes an integration profile.
+func (m *Mock) DeleteIntegrationProfile(name, profile string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	profiles := m.apiProfile[name]
+	for i, p := range profiles {
+		if p == profile {
+			m.apiProfile[name] = append(profiles[:i:i], profiles[i+1:]...)
+			return nil
+		}
+	}
+	return mockNotFound("profile", profile)
+}
+
+// TestIntegration always succeeds.
+func (m *Mock) TestIntegration(name string) error {
+	return nil
+}
+
+// ValidateIntegrationConfig requires every field to be set.
+func (m *Mock) ValidateIntegrationConfig(name string, config map[string]string) (*ConfigValidation, error) {
+	result := &ConfigValidation{Valid: true}
+	for key, value := range config {
+		if value == "" {
+			if result.Errors == nil {
+				result.Errors = make(map[string]string)
+			}
+			result.Errors[key] = "required"
+			result.Valid = false
+		}
+	}
+	return result, nil
+}
+
+// TestIntegrationProfile always succeeds.
+func (m *Mock) TestIntegrationProfile(name, profile string, config map[string]string) (*IntegrationTestResult, error) {
+	return &IntegrationTestResult{Success: true, LatencyMs: 42}, nil
+}
+
+// ListIntegrationModels returns the sample models in one page.
+func (m *Mock) ListIntegrationModels(name string, limit int, cursor string) (*ModelsResult, error) {
+	models := mockModels()
+	return &ModelsResult{Models: models, Pagination: ModelsPagination{Total: len(models), Limit: limit}}, nil
+}
+
+// --- LLM integrations ---
+
+// ListLLMProviders returns the configured providers.
+func (m *Mock) ListLLMProviders(integration string) ([]ProviderAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ProviderAccount(nil), m.providers...), nil
+}
+
+// ListAvailableLLMProviders returns the providers that can be added.
+func (m *Mock) ListAvailableLLMProviders(integration string) ([]AvailableProvider, error) {
+	return []AvailableProvider{
+		{Name: "anthropic", DisplayName: "Anthropic"},
+		{Name: "openai", DisplayName: "OpenAI"},
+		{Name: "ollama", DisplayName: "Ollama"},
+	}, nil
+}
+
+// GetLLMProviderFields returns an API key field.
+func (m *Mock) GetLLMProviderFields(integration, provider string) ([]ProviderFieldInfo, error) {
+	return []ProviderFieldInfo{
+		{Key: "api_key", Label: "API Key", Required: true, Secret: true},
+	}, nil
+}
+
+// AddLLMProvider adds a provider account.
+func (m *Mock) AddLLMProvider(integration string, req AddProviderRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.providers {
+		if m.providers[i].Provider == req.Provider {
+			m.providers[i].Accounts = append(m.providers[i].Accounts, req.Account)
+			return nil
+		}
+	}
+	m.providers = append(m.providers, ProviderAccount{
+		Provider:    req.Provider,
+		DisplayName: req.Provider,
+		Accounts:    []string{req.Account},
+	})
+	return nil
+}
+
+// DeleteLLMProvider deletes a provider account.
+func (m *Mock) DeleteLLMProvider(integration, provider, account string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.providers {
+		p := &m.providers[i]
+		if p.Provider != provider {
+			continue
+		}
+		for j, a := range p.Accounts {
+			if a == account {
+				p.Accounts = append(p.Accounts[:j:j], p.Accounts[j+1:]...)
+				if len(p.Accounts) == 0 {
+					m.providers = append(m.providers[:i:i], m.providers[i+1:]...)
+				}
+				return nil
+			}
+		}
+	}
+	return mockNotFound("provider", provider+"/"+account)
+}
+
+// ListLLMProfiles returns the LLM profiles.
+func (m *Mock) ListLLMProfiles(integration string) (*LLMProfileList, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &LLMProfileList{Profiles: append([]LLMProfile(nil), m.profiles...)}, nil
+}
+
+// CreateLLMProfile adds or replaces an LLM profile.
+func (m *Mock) CreateLLMProfile(integration string, req CreateProfileRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.profiles {
+		if p.Name == req.Name {
+			return &APIError{StatusCode: 409, Message: fmt.Sprintf("profile already exists: %s", req.Name)}
+		}
+	}
+	m.profiles = append(m.profiles, LLMProfile{Name: req.Name, Provider: req.Provider, Account: req.Account, Model: req.Model})
+	return nil
+}
+
+// UpdateLLMProfile replaces an LLM profile's settings.
+func (m *Mock) UpdateLLMProfile(integration, name string, req CreateProfileRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.profiles {
+		if m.profiles[i].Name == name {
+			m.profiles[i].Provider = req.Provider
+			m.profiles[i].Account = req.Account
+			m.profiles[i].Model = req.Model
+			return nil
+		}
+	}
+	return mockNotFound("profile", name)
+}
+
+// DeleteLLMProfile deletes an LLM profile.
+func (m *Mock) DeleteLLMProfile(integration, profile string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, p := range m.profiles {
+		if p.Name == profile {
+			m.profiles = append(m.profiles[:i:i], m.profiles[i+1:]...)
+			return nil
+		}
+	}
+	return mockNotFound("profile", profile)
+}
+
+// TestLLMProfile always succeeds.
+func (m *Mock) TestLLMProfile(integration, profile string) (*LLMTestResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.profiles {
+		if p.Name == profile {
+			return &LLMTestResult{Success: true, Model: p.Model, LatencyMs: 180}, nil
+		}
+	}
+	return nil, mockNotFound("profile", profile)
+}
+
+// ListLLMModels returns the sample models in one page.
+func (m *Mock) ListLLMModels(integration, provider string, limit int, cursor string) (*LLMModelsResult, error) {
+	models := mockModels()
+	return &LLMModelsResult{Models: models, Pagination: ModelsPagination{Total: len(models), Limit: limit}}, nil
+}
+
+// SetDefaultLLMProfile makes profile the default.
+func (m *Mock) SetDefaultLLMProfile(integration, profile string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.profiles {
+		m.profiles[i].IsDefault = m.profiles[i].Name == profile
+	}
+	return nil
+}
+
+// ClearDefaultLLMProfile unsets the default profile.
+func (m *Mock) ClearDefaultLLMProfile(integration string) error {
+	return m.SetDefaultLLMProfile(integration, "")
+}
+
+// mockModels returns the sample model list.
+func mockModels() []ModelInfo {
+	return []ModelInfo{
+		{ID: "claude-haiku", Name: "Claude Haiku", Description: "Fast and light", ContextLength: 200000},
+		{ID: "claude-sonnet", Name: "Claude Sonnet", Description: "Balanced", ContextLength: 200000},
+		{ID: "gpt-4o", Name: "GPT-4o", Description: "General purpose", ContextLength: 128000},
+	}
+}
+
+// mockNotFound returns the API error hub-core gives for a missing item.
+func mockNotFound(kind, name string) error {
+	return &APIError{StatusCode: 404, Message: fmt.Sprintf("%s not found: %s", kind, name)}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -31,10 +32,46 @@ type RunResult struct {
 
 // StepResult contains the result of a single workflow step.
 type StepResult struct {
-	StepName string      `json:"step_name"`
-	Success  bool        `json:"success"`
-	Output   interface{} `json:"output,omitempty"`
-	Error    string      `json:"error,omitempty"`
+	StepName  string      `json:"step_name"`
+	Success   bool        `json:"success"`
+	Output    interface{} `json:"output,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	StartedAt *time.Time  `json:"started_at,omitempty"`  // nil if not reported
+	EndedAt   *time.Time  `json:"finished_at,omitempty"` // nil if not reported
+}
+
+// UnmarshalJSON decodes a step result, treating an empty or null timestamp
+// as missing rather than failing the whole run.
+func (s *StepResult) UnmarshalJSON(data []byte) error {
+	type stepResult StepResult // Without this method, to avoid recursion
+	var raw struct {
+		stepResult
+		StartedAt string `json:"started_at"`
+		EndedAt   string `json:"finished_at"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = StepResult(raw.stepResult)
+
+	var err error
+	if s.StartedAt, err = parseOptionalTime(raw.StartedAt); err != nil {
+		return err
+	}
+	s.EndedAt, err = parseOptionalTime(raw.EndedAt)
+	return err
+}
+
+// parseOptionalTime parses an RFC 3339 timestamp, returning nil for "".
+func parseOptionalTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
 // Pagination contains pagination info from the API.
@@ -168,6 +205,65 @@ func (c *Client) GetRun(id string) (*Run, error) {
 	return &run, nil
 }
 
+// ErrRunNotReady is returned by GetRunWithRetry when a run that may still
+// be finalizing is missing after every attempt, usually because hub-core
+// hasn't finished writing it.
+var ErrRunNotReady = errors.New("run still finalizing")
+
+// RunRetry controls how GetRunWithRetry waits for a run to appear.
+type RunRetry struct {
+	Attempts int           // Total number of fetches, at least 1
+	Delay    time.Duration // Wait between fetches
+
+	// Whether the run may not be written yet, e.g. it was just reported
+	// finished. Only then is a missing run ErrRunNotReady; otherwise it's
+	// reported as not found.
+	Finalizing bool
+}
+
+// DefaultRunRetry covers the short window between a run finishing and
+// hub-core making it available.
+var DefaultRunRetry = RunRetry{Attempts: 3, Delay: 300 * time.Millisecond}
+
+// runFinalizeWindow is how long after finishing a run may still be
+// missing from hub-core.
+const runFinalizeWindow = time.Minute
+
+// MayBeFinalizing reports whether a run with the given status and end time
+// could still be being written by hub-core: it was running when last seen,
+// or finished moments ago.
+func MayBeFinalizing(status string, endedAt time.Time) bool {
+	if status == "running" {
+		return true
+	}
+	return !endedAt.IsZero() && time.Since(endedAt) < runFinalizeWindow
+}
+
+// GetRunWithRetry fetches a run, retrying while the server reports it as
+// not found. If it never appears, the error wraps ErrRunNotReady when
+// retry.Finalizing is set and is the server's not-found error otherwise.
+func (c *Client) GetRunWithRetry(id string, retry RunRetry) (*Run, error) {
+	return getRunWithRetry(c.GetRun, id, retry)
+}
+
+// getRunWithRetry implements GetRunWithRetry on top of a GetRun function.
+func getRunWithRetry(get func(id string) (*Run, error), id string, retry RunRetry) (*Run, error) {
+	attempts := max(retry.Attempts, 1)
+	for attempt := 1; ; attempt++ {
+		run, err := get(id)
+		if !IsNotFound(err) {
+			return run, err
+		}
+		if attempt >= attempts {
+			if !retry.Finalizing {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %w", ErrRunNotReady, err)
+		}
+		time.Sleep(retry.Delay)
+	}
+}
+
 // CancelRun cancels a running workflow.
 func (c *Client) CancelRun(id string) error {
 	resp, err := c.post("/runs/"+id+"/cancel", nil)
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc is a fake transport answering requests in-process.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeClient returns a client whose requests are answered by rt.
+func fakeClient(rt roundTripFunc) *Client {
+	c := New("http://hub.test")
+	c.httpClient.Transport = rt
+	return c
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestGetRunWithRetry(t *testing.T) {
+	const runJSON = `{"id":"run-1","workflow":"digest","status":"completed"}`
+	retry := RunRetry{Attempts: 3, Delay: time.Millisecond}
+
+	tests := []struct {
+		name       string
+		missing    int // Requests answered 404 before the run appears
+		finalizing bool
+		wantCalls  int
+		wantErr    func(error) bool
+	}{
+		{"found at once", 0, false, 1, nil},
+		{"found after retry", 2, true, 3, nil},
+		{"never appears while finalizing", 5, true, 3, func(err error) bool {
+			return errors.Is(err, ErrRunNotReady) && IsNotFound(err)
+		}},
+		{"never appears otherwise", 5, false, 3, func(err error) bool {
+			return !errors.Is(err, ErrRunNotReady) && IsNotFound(err)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			c := fakeClient(func(req *http.Request) (*http.Response, error) {
+				calls++
+				if req.URL.Path != "/runs/run-1" {
+					t.Errorf("requested %s, want /runs/run-1", req.URL.Path)
+				}
+				if calls <= tt.missing {
+					return jsonResponse(http.StatusNotFound, `{"error":"run not found"}`), nil
+				}
+				return jsonResponse(http.StatusOK, runJSON), nil
+			})
+
+			r := retry
+			r.Finalizing = tt.finalizing
+			run, err := c.GetRunWithRetry("run-1", r)
+			if calls != tt.wantCalls {
+				t.Errorf("made %d requests, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr != nil {
+				if err == nil || !tt.wantErr(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRunWithRetry: %v", err)
+			}
+			if run.ID != "run-1" {
+				t.Errorf("run ID = %q, want run-1", run.ID)
+			}
+		})
+	}
+}
+
+func TestGetRunWithRetryStopsOnOtherErrors(t *testing.T) {
+	calls := 0
+	c := fakeClient(func(*http.Request) (*http.Response, error) {
+		calls++
+		return jsonResponse(http.StatusInternalServerError, `{"error":"boom"}`), nil
+	})
+
+	_, err := c.GetRunWithRetry("run-1", RunRetry{Attempts: 3, Finalizing: true})
+	if !IsServerError(err) || errors.Is(err, ErrRunNotReady) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("made %d requests, want 1", calls)
+	}
+}
+
+func TestMayBeFinalizing(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		status  string
+		endedAt time.Time
+		want    bool
+	}{
+		{"running", "running", time.Time{}, true},
+		{"just finished", "completed", now.Add(-5 * time.Second), true},
+		{"finished long ago", "completed", now.Add(-time.Hour), false},
+		{"no end time", "failed", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MayBeFinalizing(tt.status, tt.endedAt); got != tt.want {
+				t.Errorf("MayBeFinalizing(%q, %v) = %v, want %v", tt.status, tt.endedAt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepResultTimestamps(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantStarted bool
+		wantEnded   bool
+	}{
+		{"both set", `{"step_name":"a","started_at":"2026-01-02T03:04:05Z","finished_at":"2026-01-02T03:04:09.5Z"}`, true, true},
+		{"missing", `{"step_name":"a"}`, false, false},
+		{"null", `{"step_name":"a","started_at":null,"finished_at":null}`, false, false},
+		{"empty", `{"step_name":"a","started_at":"","finished_at":""}`, false, false},
+		{"only started", `{"step_name":"a","started_at":"2026-01-02T03:04:05Z"}`, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s StepResult
+			if err := json.Unmarshal([]byte(tt.json), &s); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if s.StepName != "a" {
+				t.Errorf("step name = %q, want a", s.StepName)
+			}
+			if (s.StartedAt != nil) != tt.wantStarted {
+				t.Errorf("StartedAt = %v, want set %v", s.StartedAt, tt.wantStarted)
+			}
+			if (s.EndedAt != nil) != tt.wantEnded {
+				t.Errorf("EndedAt = %v, want set %v", s.EndedAt, tt.wantEnded)
+			}
+		})
+	}
+
+	// A run with a blank step timestamp still decodes
+	var run Run
+	body := `{"id":"run-1","result":{"steps":[{"step_name":"a","started_at":""}]}}`
+	if err := json.Unmarshal([]byte(body), &run); err != nil {
+		t.Fatalf("Unmarshal run: %v", err)
+	}
+
+	// Missing timestamps are left out when re-encoded
+	out, err := json.Marshal(StepResult{StepName: "a"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(out), "started_at") || strings.Contains(string(out), "finished_at") {
+		t.Errorf("Marshal = %s, want no timestamps", out)
+	}
+}
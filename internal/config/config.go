@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config holds the hub-tui configuration.
@@ -11,6 +12,138 @@ type Config struct {
 	ServerURL string `json:"server_url"`
 	Token     string `json:"token,omitempty"`
 	TokenExp  string `json:"token_expires,omitempty"`
+
+	// ConfirmTimeoutMs is the double-press confirmation window in milliseconds.
+	// Zero means use the built-in default.
+	ConfirmTimeoutMs int `json:"confirm_timeout_ms,omitempty"`
+
+	// MaxInputLines is the maximum height of the chat input in lines.
+	// Zero means use the built-in default.
+	MaxInputLines int `json:"max_input_lines,omitempty"`
+
+	// InputCharLimit is the maximum length of a chat message in characters.
+	// Zero means use the built-in default.
+	InputCharLimit int `json:"input_char_limit,omitempty"`
+
+	// SharedTranscript keeps a single chat history across contexts instead
+	// of a separate one for the hub and each assistant.
+	SharedTranscript bool `json:"shared_transcript,omitempty"`
+
+	// CompactChat drops the blank line between chat messages, for short
+	// terminals.
+	CompactChat bool `json:"compact_chat,omitempty"`
+
+	// HideRouteTrace hides the chat line noting where /ask routed a
+	// message.
+	HideRouteTrace bool `json:"hide_route_trace,omitempty"`
+
+	// Mouse turns on mouse support: wheel scrolling and clicks in modals.
+	// Off by default, leaving the mouse to the terminal's native text
+	// selection.
+	Mouse bool `json:"mouse,omitempty"`
+
+	// ContextType and ContextTarget remember the active assistant
+	// context so it can be restored on the next start.
+	ContextType   string `json:"context_type,omitempty"`
+	ContextTarget string `json:"context_target,omitempty"`
+
+	// AbsoluteTaskTimes shows exact timestamps instead of elapsed times
+	// in the task list.
+	AbsoluteTaskTimes bool `json:"absolute_task_times,omitempty"`
+
+	// EnabledWorkflowsOnly hides disabled workflows in the workflows list.
+	EnabledWorkflowsOnly bool `json:"enabled_workflows_only,omitempty"`
+
+	// CodeTheme is the chroma theme for highlighting code blocks.
+	// Empty means use the default for the terminal's background.
+	CodeTheme string `json:"code_theme,omitempty"`
+
+	// ProfileOrders lists each LLM integration's profile names in display
+	// order, keyed by integration name. Profiles not listed follow
+	// alphabetically.
+	ProfileOrders map[string][]string `json:"profile_orders,omitempty"`
+
+	// Keybindings overrides default key bindings, mapping an action name
+	// (e.g. "quit", "prev_message") to comma-separated keys ("ctrl+q").
+	Keybindings map[string]string `json:"keybindings,omitempty"`
+
+	// NotifyOnAttention rings the terminal bell and sends a desktop
+	// notification when a workflow run newly needs attention.
+	NotifyOnAttention bool `json:"notify_on_attention,omitempty"`
+
+	// RecentServers lists previously used server URLs, most recent first.
+	RecentServers []string `json:"recent_servers,omitempty"`
+
+	memoryOnly bool   // Save is a no-op
+	path       string // File Save writes to, "" for DefaultPath
+
+	// Credentials from the environment, and the file's values they
+	// replaced, so Save doesn't write environment values to the file
+	env  credentials
+	file credentials
+}
+
+// credentials are the config fields the environment can override.
+type credentials struct {
+	serverURL string
+	token     string
+	tokenExp  string
+}
+
+// Environment variables that override the config file. They take
+// precedence over the file's values but are never saved to it.
+const (
+	EnvServerURL = "HUB_TUI_SERVER"
+	EnvToken     = "HUB_TUI_TOKEN"
+)
+
+// NewInMemory returns an empty config that is never written to disk.
+func NewInMemory() *Config {
+	return &Config{memoryOnly: true}
+}
+
+// MouseEnabled returns true if mouse support is enabled.
+func (c *Config) MouseEnabled() bool {
+	return c.Mouse
+}
+
+// maxRecentServers caps the server URL history.
+const maxRecentServers = 5
+
+// AddRecentServer moves serverURL to the front of the server history.
+func (c *Config) AddRecentServer(serverURL string) {
+	if serverURL == "" {
+		return
+	}
+	recent := []string{serverURL}
+	for _, s := range c.RecentServers {
+		if s != serverURL && len(recent) < maxRecentServers {
+			recent = append(recent, s)
+		}
+	}
+	c.RecentServers = recent
+}
+
+// ConfirmTimeout returns the configured confirmation window.
+// Returns zero if unset, which callers treat as the default.
+func (c *Config) ConfirmTimeout() time.Duration {
+	if c.ConfirmTimeoutMs <= 0 {
+		return 0
+	}
+	return time.Duration(c.ConfirmTimeoutMs) * time.Millisecond
+}
+
+// JSON returns the config as indented JSON for display, with the token redacted.
+func (c *Config) JSON() (string, error) {
+	display := *c
+	if display.Token != "" {
+		display.Token = "(redacted)"
+	}
+	data, err := json.MarshalIndent(display, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
 }
 
 // DefaultPath returns the default config file path.
@@ -32,13 +165,16 @@ func Load() (*Config, error) {
 	return LoadFrom(path)
 }
 
-// LoadFrom reads the config from the specified path.
+// LoadFrom reads the config from the specified path. Save writes back to
+// the same path.
 // If the file doesn't exist, returns a zero Config (not an error).
 func LoadFrom(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &Config{}, nil
+			cfg := &Config{path: path}
+			cfg.applyEnv()
+			return cfg, nil
 		}
 		return nil, err
 	}
@@ -47,12 +183,56 @@ func LoadFrom(path string) (*Config, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	cfg.path = path
+	cfg.applyEnv()
 	return &cfg, nil
 }
 
-// Save writes the config to the default path.
+// applyEnv overrides the server URL and token with EnvServerURL and
+// EnvToken when they're set. An environment token has no known expiry, so
+// the file's expiry is dropped along with the file's token.
+func (c *Config) applyEnv() {
+	c.file = credentials{serverURL: c.ServerURL, token: c.Token, tokenExp: c.TokenExp}
+	if url := os.Getenv(EnvServerURL); url != "" {
+		c.env.serverURL = url
+		c.ServerURL = url
+	}
+	if token := os.Getenv(EnvToken); token != "" {
+		c.env.token = token
+		c.Token = token
+		c.TokenExp = ""
+	}
+}
+
+// fileView returns the config as it should be written to the file: values
+// still coming from the environment are replaced by the file's own.
+func (c *Config) fileView() Config {
+	saved := *c
+	if c.env.serverURL != "" && saved.ServerURL == c.env.serverURL {
+		saved.ServerURL = c.file.serverURL
+	}
+	if c.env.token != "" && saved.Token == c.env.token {
+		saved.Token = c.file.token
+		saved.TokenExp = c.file.tokenExp
+	}
+	return saved
+}
+
+// Path returns the file the config is saved to.
+func (c *Config) Path() (string, error) {
+	if c.path != "" {
+		return c.path, nil
+	}
+	return DefaultPath()
+}
+
+// Save writes the config to the path it was loaded from, or the default
+// path. In-memory configs are not saved.
 func (c *Config) Save() error {
-	path, err := DefaultPath()
+	if c.memoryOnly {
+		return nil
+	}
+	path, err := c.Path()
 	if err != nil {
 		return err
 	}
@@ -66,7 +246,8 @@ func (c *Config) SaveTo(path string) error {
 		return err
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
+	saved := c.fileView()
+	data, err := json.MarshalIndent(&saved, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// paramsFile is the sidecar file, next to the config file, holding the
+// last parameters submitted for each workflow or module.
+const paramsFile = "params.json"
+
+// paramsPath returns the path of the parameters sidecar file.
+func (c *Config) paramsPath() (string, error) {
+	path, err := c.Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), paramsFile), nil
+}
+
+// loadAllParams reads every target's remembered parameters.
+// A missing file is not an error.
+func (c *Config) loadAllParams() (map[string]map[string]interface{}, error) {
+	path, err := c.paramsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+	all := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// LastParams returns the parameters last submitted for target, or nil if
+// none were saved. Values have their JSON types (float64 for numbers,
+// []interface{} for arrays).
+func (c *Config) LastParams(target string) map[string]interface{} {
+	if c.memoryOnly {
+		return nil
+	}
+	all, err := c.loadAllParams()
+	if err != nil {
+		return nil
+	}
+	return all[target]
+}
+
+// SaveParams remembers params as the last submitted for target.
+// In-memory configs don't save.
+func (c *Config) SaveParams(target string, params map[string]interface{}) error {
+	if c.memoryOnly {
+		return nil
+	}
+	all, err := c.loadAllParams()
+	if err != nil {
+		// Start over rather than keep failing on a corrupt file
+		all = map[string]map[string]interface{}{}
+	}
+	all[target] = params
+
+	path, err := c.paramsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -15,6 +15,7 @@ type Autocomplete struct {
 	selected    int
 	prefix      InputPrefix
 	partial     string // The partial text being completed
+	message     string // Shown in place of suggestions when there are none
 	width       int
 }
 
@@ -28,10 +29,12 @@ func (a *Autocomplete) SetWidth(width int) {
 	a.width = width
 }
 
-// Show displays the autocomplete with the given suggestions.
-func (a *Autocomplete) Show(prefix InputPrefix, partial string, suggestions []string) {
+// Show displays the autocomplete with the given suggestions. If there are no
+// suggestions, message explains why.
+func (a *Autocomplete) Show(prefix InputPrefix, partial string, suggestions []string, message string) {
 	a.visible = true
 	a.suggestions = suggestions
+	a.message = message
 	a.selected = 0
 	a.prefix = prefix
 	a.partial = partial
@@ -41,6 +44,7 @@ func (a *Autocomplete) Show(prefix InputPrefix, partial string, suggestions []st
 func (a *Autocomplete) Hide() {
 	a.visible = false
 	a.suggestions = nil
+	a.message = ""
 	a.selected = 0
 }
 
@@ -49,6 +53,11 @@ func (a Autocomplete) IsVisible() bool {
 	return a.visible
 }
 
+// HasSuggestions returns true if there are suggestions to select.
+func (a Autocomplete) HasSuggestions() bool {
+	return len(a.suggestions) > 0
+}
+
 // MoveUp moves selection up.
 func (a *Autocomplete) MoveUp() {
 	if a.selected > 0 {
@@ -82,7 +91,7 @@ func (a Autocomplete) Prefix() InputPrefix {
 
 // View renders the autocomplete menu.
 func (a Autocomplete) View() string {
-	if !a.visible || len(a.suggestions) == 0 {
+	if !a.visible || (len(a.suggestions) == 0 && a.message == "") {
 		return ""
 	}
 
@@ -90,7 +99,14 @@ func (a Autocomplete) View() string {
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(theme.Surface).
 		Padding(0, 1).
-		Width(a.width - 4)
+		Width(max(a.width-4, 0))
+
+	if len(a.suggestions) == 0 {
+		messageStyle := lipgloss.NewStyle().
+			Foreground(theme.TextSecondary).
+			Italic(true)
+		return menuStyle.Render(messageStyle.Render(a.message))
+	}
 
 	var items []string
 	for i, s := range a.suggestions {
@@ -2,17 +2,27 @@ package chat
 
 import (
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
 const (
-	scrollPageSize = 10
+	scrollPageSize  = 10
+	mouseWheelLines = 3 // Lines scrolled per mouse wheel notch
+
+	thinkingInterval = 100 * time.Millisecond // Delay between thinking indicator frames
 )
 
+// ThinkingTickMsg advances the indicator shown on a hub message that is
+// waiting for its first chunk.
+type ThinkingTickMsg struct{}
+
 // Model is the chat view component.
 type Model struct {
 	messages     []Message
@@ -23,6 +33,21 @@ type Model struct {
 	scrollPos    int  // Current scroll position (0 = bottom)
 	autoScroll   bool // Whether to auto-scroll on new messages
 	inContext    bool // Whether in assistant context (for input border)
+	compact      bool // No spacing between messages
+	raw          bool // Show hub replies as markdown source
+	keys         *keys.Keymap
+
+	// Indicator shown until the first chunk of a reply arrives
+	thinking        *components.Spinner
+	thinkingTicking bool
+}
+
+// Transcript is a saved message list and scroll position,
+// used to keep a separate conversation per context.
+type Transcript struct {
+	messages   []Message
+	scrollPos  int
+	autoScroll bool
 }
 
 // New creates a new chat model.
@@ -32,22 +57,70 @@ func New() Model {
 		input:        NewInput(),
 		autocomplete: NewAutocomplete(),
 		autoScroll:   true,
+		keys:         keys.Default(),
+		thinking:     components.NewSpinner(),
 	}
 }
 
+// SetKeymap sets the key bindings for scrolling, message jumps and the input.
+func (m *Model) SetKeymap(k *keys.Keymap) {
+	m.keys = k
+	m.input.keys = k
+}
+
 // SetSize sets the chat view dimensions.
 func (m *Model) SetSize(width, height int) {
+	width, height = max(width, 0), max(height, 0)
+	if width != m.width {
+		// Renderers for the old width won't be used again
+		resetRenderers()
+	}
 	m.width = width
 	m.height = height
 	m.input.SetWidth(width)
 	m.autocomplete.SetWidth(width)
 }
 
+// SetMaxInputLines sets the maximum height of the input in lines.
+// Non-positive values use DefaultMaxInputLines.
+func (m *Model) SetMaxInputLines(n int) {
+	m.input.SetMaxLines(n)
+}
+
+// SetInputCharLimit sets the maximum input length in characters.
+// Non-positive values use DefaultInputCharLimit.
+func (m *Model) SetInputCharLimit(n int) {
+	m.input.SetCharLimit(n)
+}
+
+// SetAttachment shows label above the input as a file attached to the next
+// message. An empty label clears it.
+func (m *Model) SetAttachment(label string) {
+	m.input.attachment = label
+}
+
 // SetInContext sets whether chat is in assistant context (affects input border).
 func (m *Model) SetInContext(inContext bool) {
 	m.inContext = inContext
 }
 
+// SetCompact sets whether messages are packed without blank lines between
+// them, for short terminals.
+func (m *Model) SetCompact(compact bool) {
+	m.compact = compact
+}
+
+// SetRaw sets whether hub replies show their markdown source instead of
+// the rendered markdown.
+func (m *Model) SetRaw(raw bool) {
+	m.raw = raw
+}
+
+// Raw reports whether hub replies show their markdown source.
+func (m Model) Raw() bool {
+	return m.raw
+}
+
 // AddUserMessage adds a user message to the chat.
 func (m *Model) AddUserMessage(content string) {
 	m.messages = append(m.messages, NewUserMessage(content))
@@ -57,11 +130,30 @@ func (m *Model) AddUserMessage(content string) {
 }
 
 // AddHubMessage adds a new hub message (for streaming).
-func (m *Model) AddHubMessage() {
+// Returns a command animating the thinking indicator until the first
+// chunk arrives.
+func (m *Model) AddHubMessage() tea.Cmd {
 	m.messages = append(m.messages, NewHubMessage())
 	if m.autoScroll {
 		m.scrollPos = 0
 	}
+	if m.thinkingTicking {
+		return nil
+	}
+	m.thinkingTicking = true
+	return thinkingTick()
+}
+
+// thinkingTick schedules the next frame of the thinking indicator.
+func thinkingTick() tea.Cmd {
+	return tea.Tick(thinkingInterval, func(time.Time) tea.Msg {
+		return ThinkingTickMsg{}
+	})
+}
+
+// isThinking reports whether the last message is waiting for its first chunk.
+func (m Model) isThinking() bool {
+	return len(m.messages) > 0 && m.messages[len(m.messages)-1].IsThinking()
 }
 
 // AddSystemMessage adds a system message to the chat.
@@ -72,6 +164,28 @@ func (m *Model) AddSystemMessage(content string) {
 	}
 }
 
+// SetReplySource tags the reply being streamed with what /ask routed it
+// to, shown as a badge on the reply.
+func (m *Model) SetReplySource(sourceType string) {
+	if m.IsStreaming() {
+		m.messages[len(m.messages)-1].SourceType = sourceType
+	}
+}
+
+// AddNoteBeforeReply adds a system message just above the reply being
+// streamed, or at the end if nothing is streaming.
+func (m *Model) AddNoteBeforeReply(content string) {
+	if !m.IsStreaming() {
+		m.AddSystemMessage(content)
+		return
+	}
+	last := len(m.messages) - 1
+	m.messages = append(m.messages[:last], NewSystemMessage(content), m.messages[last])
+	if m.autoScroll {
+		m.scrollPos = 0
+	}
+}
+
 // ClearMessages clears all messages from the chat.
 func (m *Model) ClearMessages() {
 	m.messages = make([]Message, 0)
@@ -79,6 +193,46 @@ func (m *Model) ClearMessages() {
 	m.autoScroll = true
 }
 
+// SwapTranscript replaces the current messages and scroll position with t
+// and returns the previous ones. A zero Transcript starts an empty chat.
+func (m *Model) SwapTranscript(t Transcript) Transcript {
+	prev := Transcript{
+		messages:   m.messages,
+		scrollPos:  m.scrollPos,
+		autoScroll: m.autoScroll,
+	}
+	if t.messages == nil {
+		t = Transcript{messages: make([]Message, 0), autoScroll: true}
+	}
+	m.messages = t.messages
+	m.scrollPos = t.scrollPos
+	m.autoScroll = t.autoScroll
+	return prev
+}
+
+// TakePendingExchange removes and returns the streaming reply together with
+// the user message that prompted it. Returns nil if nothing is streaming.
+func (m *Model) TakePendingExchange() []Message {
+	if !m.IsStreaming() {
+		return nil
+	}
+	start := len(m.messages) - 1
+	if start > 0 && m.messages[start-1].Role == RoleUser {
+		start--
+	}
+	pending := append([]Message(nil), m.messages[start:]...)
+	m.messages = m.messages[:start]
+	return pending
+}
+
+// AppendMessages adds existing messages to the end of the chat.
+func (m *Model) AppendMessages(msgs []Message) {
+	m.messages = append(m.messages, msgs...)
+	if m.autoScroll {
+		m.scrollPos = 0
+	}
+}
+
 // AppendToLastMessage appends content to the last message.
 func (m *Model) AppendToLastMessage(chunk string) {
 	if len(m.messages) > 0 {
@@ -93,13 +247,56 @@ func (m *Model) FinishLastMessage() {
 	}
 }
 
+// AppendErrorToLastMessage shows text at the end of the last message,
+// after any part of the reply that arrived, so a failed reply can be
+// regenerated like any other.
+func (m *Model) AppendErrorToLastMessage(text string) {
+	if len(m.messages) == 0 {
+		return
+	}
+	last := &m.messages[len(m.messages)-1]
+	if last.hasContent {
+		text = "\n\n" + text
+	}
+	last.AppendContent(text)
+}
+
 // ReplaceLastMessageContent replaces the content of the last message.
 func (m *Model) ReplaceLastMessageContent(content string) {
 	if len(m.messages) > 0 {
 		m.messages[len(m.messages)-1].Content = content
+		m.messages[len(m.messages)-1].hasContent = true
 	}
 }
 
+// RemoveLastHubMessage removes the last message if it is a finished hub message.
+// Returns true if a message was removed.
+func (m *Model) RemoveLastHubMessage() bool {
+	if len(m.messages) == 0 {
+		return false
+	}
+	last := m.messages[len(m.messages)-1]
+	if last.Role != RoleHub || last.Streaming {
+		return false
+	}
+	m.messages = m.messages[:len(m.messages)-1]
+	return true
+}
+
+// RemoveLastNote removes the last message if it is a system message
+// starting with prefix. Returns true if a message was removed.
+func (m *Model) RemoveLastNote(prefix string) bool {
+	if len(m.messages) == 0 {
+		return false
+	}
+	last := m.messages[len(m.messages)-1]
+	if last.Role != RoleSystem || !strings.HasPrefix(last.Content, prefix) {
+		return false
+	}
+	m.messages = m.messages[:len(m.messages)-1]
+	return true
+}
+
 // MessageCount returns the number of messages.
 func (m Model) MessageCount() int {
 	return len(m.messages)
@@ -112,6 +309,27 @@ func (m *Model) UpdateMessageContent(idx int, content string) {
 	}
 }
 
+// SetSteps attaches workflow steps to the hub message at idx, shown
+// collapsed under it.
+func (m *Model) SetSteps(idx int, steps []Step) {
+	if idx >= 0 && idx < len(m.messages) && m.messages[idx].Role == RoleHub {
+		m.messages[idx].Steps = steps
+		m.messages[idx].StepsExpanded = false
+	}
+}
+
+// toggleSteps expands or collapses the steps of the most recent message
+// that has any. Returns false if no message has steps.
+func (m *Model) toggleSteps() bool {
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if len(m.messages[i].Steps) > 0 {
+			m.messages[i].StepsExpanded = !m.messages[i].StepsExpanded
+			return true
+		}
+	}
+	return false
+}
+
 // IsStreaming returns true if currently receiving a response.
 func (m Model) IsStreaming() bool {
 	if len(m.messages) == 0 {
@@ -135,9 +353,10 @@ func (m *Model) FocusInput() {
 	m.input.Focus()
 }
 
-// ShowAutocomplete shows the autocomplete menu with suggestions.
-func (m *Model) ShowAutocomplete(prefix InputPrefix, partial string, suggestions []string) {
-	m.autocomplete.Show(prefix, partial, suggestions)
+// ShowAutocomplete shows the autocomplete menu with suggestions, or message
+// if there are none.
+func (m *Model) ShowAutocomplete(prefix InputPrefix, partial string, suggestions []string, message string) {
+	m.autocomplete.Show(prefix, partial, suggestions, message)
 }
 
 // HideAutocomplete hides the autocomplete menu.
@@ -150,6 +369,11 @@ func (m Model) IsAutocompleteVisible() bool {
 	return m.autocomplete.IsVisible()
 }
 
+// HasAutocompleteSuggestions returns true if the autocomplete has suggestions to select.
+func (m Model) HasAutocompleteSuggestions() bool {
+	return m.autocomplete.HasSuggestions()
+}
+
 // AutocompleteUp moves autocomplete selection up.
 func (m *Model) AutocompleteUp() {
 	m.autocomplete.MoveUp()
@@ -202,37 +426,72 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case ThinkingTickMsg:
+		// Stop ticking once the reply starts or is abandoned
+		if !m.isThinking() {
+			m.thinkingTicking = false
+			return m, nil
+		}
+		m.thinking.Advance()
+		return m, thinkingTick()
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "up":
-			// Scroll up if input is empty or at beginning
+		// Keys that would type text only act on an empty input
+		free := m.input.IsEmpty() || !keys.IsText(msg)
+		switch {
+		case m.keys.Is(msg, keys.ScrollUp):
+			// Scroll up if input is empty
 			if m.input.IsEmpty() {
 				m.scrollUp(1)
 				return m, nil
 			}
-		case "down":
+		case m.keys.Is(msg, keys.ScrollDown):
 			// Scroll down
 			if m.input.IsEmpty() {
 				m.scrollDown(1)
 				return m, nil
 			}
-		case "pgup":
+		case m.keys.Is(msg, keys.PageUp) && free:
 			m.scrollUp(scrollPageSize)
 			return m, nil
-		case "pgdown":
+		case m.keys.Is(msg, keys.PageDown) && free:
 			m.scrollDown(scrollPageSize)
 			return m, nil
-		case "home":
+		case m.keys.Is(msg, keys.ScrollTop) && free:
 			// Scroll to top
 			m.scrollPos = m.maxScroll()
 			m.autoScroll = false
 			return m, nil
-		case "end":
+		case m.keys.Is(msg, keys.ScrollBottom) && free:
 			// Scroll to bottom
 			m.scrollPos = 0
 			m.autoScroll = true
 			return m, nil
+		case m.keys.Is(msg, keys.PrevMessage) && free:
+			m.jumpToMessage(-1)
+			return m, nil
+		case m.keys.Is(msg, keys.NextMessage) && free:
+			m.jumpToMessage(1)
+			return m, nil
+		case m.keys.Is(msg, keys.ToggleSteps) && free:
+			if m.toggleSteps() {
+				return m, nil
+			}
+		}
+
+	case tea.MouseMsg:
+		// Only wheel events over the messages area scroll; everything
+		// else is left alone
+		if msg.Action != tea.MouseActionPress || msg.Y >= m.messagesHeight() {
+			return m, nil
 		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scrollUp(mouseWheelLines)
+		case tea.MouseButtonWheelDown:
+			m.scrollDown(mouseWheelLines)
+		}
+		return m, nil
 	}
 
 	// Update input
@@ -268,16 +527,103 @@ func (m Model) maxScroll() int {
 	return totalLines - visibleLines
 }
 
+// jumpToMessage scrolls so the previous (delta < 0) or next (delta > 0)
+// message starts at the top of the messages area.
+func (m *Model) jumpToMessage(delta int) {
+	offsets := m.MessageOffsets()
+	if len(offsets) == 0 {
+		return
+	}
+
+	// Rendered lines, without the spacing counted after the last message
+	totalLines := m.countMessageLines() - m.messageGap()
+	height := m.messagesHeight()
+	top := totalLines - height - m.scrollPos
+	if top < 0 {
+		top = 0
+	}
+
+	target := -1
+	if delta < 0 {
+		for i := len(offsets) - 1; i >= 0; i-- {
+			if offsets[i] < top {
+				target = offsets[i]
+				break
+			}
+		}
+	} else {
+		for _, offset := range offsets {
+			if offset > top {
+				target = offset
+				break
+			}
+		}
+	}
+	if target < 0 {
+		return
+	}
+
+	m.scrollPos = totalLines - height - target
+	if maxScroll := m.maxScroll(); m.scrollPos > maxScroll {
+		m.scrollPos = maxScroll
+	}
+	if m.scrollPos < 0 {
+		m.scrollPos = 0
+	}
+	m.autoScroll = false
+}
+
+// MessageOffsets returns the line each message starts on in the rendered
+// transcript.
+func (m Model) MessageOffsets() []int {
+	offsets := make([]int, len(m.messages))
+	line := 0
+	for i, msg := range m.messages {
+		offsets[i] = line
+		rendered := m.messageView(msg)
+		line += strings.Count(rendered, "\n") + 1
+		line += m.messageGap()
+	}
+	return offsets
+}
+
 func (m Model) countMessageLines() int {
 	total := 0
 	for _, msg := range m.messages {
-		rendered := msg.View(m.width)
+		rendered := m.messageView(msg)
 		total += strings.Count(rendered, "\n") + 1
-		total++ // Add spacing between messages
+		total += m.messageGap()
 	}
 	return total
 }
 
+// messageGap returns the number of blank lines between messages.
+func (m Model) messageGap() int {
+	if m.compact {
+		return 0
+	}
+	return 1
+}
+
+// messageView renders a message for the transcript. In compact mode
+// system messages also drop their blank lines.
+func (m Model) messageView(msg Message) string {
+	if msg.IsThinking() {
+		return hubSymbolStyle.Render(HubSymbol) + "  " + m.thinking.View("thinking…")
+	}
+	if m.compact && msg.Role == RoleSystem {
+		lines := strings.Split(msg.Content, "\n")
+		kept := lines[:0]
+		for _, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				kept = append(kept, line)
+			}
+		}
+		msg.Content = strings.Join(kept, "\n")
+	}
+	return msg.View(m.width, m.raw)
+}
+
 func (m Model) messagesHeight() int {
 	// Total height minus input area (3 lines typically) minus status bar (1 line)
 	inputHeight := strings.Count(m.input.View(), "\n") + 1
@@ -348,6 +694,9 @@ func (m Model) ViewWithHeight(height int) string {
 }
 
 func (m Model) renderMessages(height int) string {
+	// The input and status bar can leave no room on a tiny terminal
+	height = max(height, 0)
+
 	if len(m.messages) == 0 {
 		// Show placeholder when no messages
 		placeholder := lipgloss.NewStyle().
@@ -367,9 +716,9 @@ func (m Model) renderMessages(height int) string {
 	// Render all messages
 	var lines []string
 	for i, msg := range m.messages {
-		rendered := msg.View(m.width)
+		rendered := m.messageView(msg)
 		lines = append(lines, rendered)
-		if i < len(m.messages)-1 {
+		if i < len(m.messages)-1 && !m.compact {
 			lines = append(lines, "") // Spacing between messages
 		}
 	}
@@ -0,0 +1,30 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestJumpToMessageAlignsTop(t *testing.T) {
+	for _, compact := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compact=%v", compact), func(t *testing.T) {
+			m := New()
+			m.SetCompact(compact)
+			m.SetSize(60, 14)
+			for i := 0; i < 12; i++ {
+				m.AddSystemMessage(fmt.Sprintf("message %d\nsecond line", i))
+			}
+			height := m.messagesHeight()
+
+			// Each jump back puts the start of a message on the top line
+			for step := 0; step < 4; step++ {
+				m.jumpToMessage(-1)
+				top := strings.Split(m.renderMessages(height), "\n")[0]
+				if !strings.Contains(top, "message ") {
+					t.Fatalf("after %d jumps the top line is %q, want the start of a message", step+1, top)
+				}
+			}
+		})
+	}
+}
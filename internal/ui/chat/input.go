@@ -1,19 +1,38 @@
 package chat
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
+const (
+	// DefaultMaxInputLines is the default maximum height of the input in lines.
+	DefaultMaxInputLines = 5
+
+	// DefaultInputCharLimit is the default maximum input length in characters.
+	DefaultInputCharLimit = 4096
+
+	// Fractions of the character limit at which the counter is shown
+	// and at which it switches to the warning color.
+	counterShowRatio = 0.8
+	counterWarnRatio = 0.95
+)
+
 // Input is the chat input component.
 type Input struct {
 	textarea textarea.Model
 	width    int
+	maxLines int // Maximum height the input grows to
+	keys     *keys.Keymap
+
+	attachment string // Label of a file sent with the next message
 }
 
 // NewInput creates a new chat input.
@@ -21,7 +40,7 @@ func NewInput() Input {
 	ta := textarea.New()
 	ta.Placeholder = "Type a message..."
 	ta.ShowLineNumbers = false
-	ta.CharLimit = 4096
+	ta.CharLimit = DefaultInputCharLimit
 	ta.SetHeight(1)
 	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
 	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(theme.TextSecondary)
@@ -33,13 +52,34 @@ func NewInput() Input {
 
 	return Input{
 		textarea: ta,
+		maxLines: DefaultMaxInputLines,
+		keys:     keys.Default(),
+	}
+}
+
+// SetMaxLines sets the maximum height the input grows to.
+// Non-positive values use DefaultMaxInputLines.
+func (i *Input) SetMaxLines(n int) {
+	if n <= 0 {
+		n = DefaultMaxInputLines
 	}
+	i.maxLines = n
+	i.recomputeHeight()
+}
+
+// SetCharLimit sets the maximum input length in characters.
+// Non-positive values use DefaultInputCharLimit.
+func (i *Input) SetCharLimit(n int) {
+	if n <= 0 {
+		n = DefaultInputCharLimit
+	}
+	i.textarea.CharLimit = n
 }
 
 // SetWidth sets the input width.
 func (i *Input) SetWidth(width int) {
 	i.width = width
-	i.textarea.SetWidth(width - 2) // Account for border/padding
+	i.textarea.SetWidth(max(width-2, 0)) // Account for border/padding
 }
 
 // Focus focuses the input.
@@ -60,6 +100,7 @@ func (i Input) Value() string {
 // SetValue sets the input text.
 func (i *Input) SetValue(s string) {
 	i.textarea.SetValue(s)
+	i.recomputeHeight()
 }
 
 // Reset clears the input.
@@ -68,6 +109,11 @@ func (i *Input) Reset() {
 	i.textarea.SetHeight(1)
 }
 
+// Length returns the number of characters in the input.
+func (i *Input) Length() int {
+	return i.textarea.Length()
+}
+
 // IsEmpty returns true if the input is empty.
 func (i Input) IsEmpty() bool {
 	return i.Value() == ""
@@ -79,27 +125,45 @@ func (i Input) Update(msg tea.Msg) (Input, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		if msg.Paste {
+			// Bracketed paste: insert as-is so pasted newlines don't submit
+			text := strings.ReplaceAll(string(msg.Runes), "\r\n", "\n")
+			text = strings.ReplaceAll(text, "\r", "\n")
+			i.textarea.InsertString(text)
+			i.recomputeHeight()
+			return i, nil
+		}
+
+		if msg.String() == "enter" {
 			// Don't handle enter here - let parent handle submission
 			return i, nil
-		case "ctrl+j", "alt+enter":
-			// Ctrl+J inserts newline (standard terminal newline)
+		}
+		if i.keys.Is(msg, keys.Newline) {
+			// Ctrl+J by default (standard terminal newline);
 			// Alt+Enter also works in some terminals
 			i.textarea.InsertString("\n")
-			// Grow input if needed (up to 5 lines)
-			lines := strings.Count(i.textarea.Value(), "\n") + 1
-			if lines > 1 && lines <= 5 {
-				i.textarea.SetHeight(lines)
-			}
+			i.recomputeHeight()
 			return i, nil
 		}
 	}
 
 	i.textarea, cmd = i.textarea.Update(msg)
+	i.recomputeHeight()
 	return i, cmd
 }
 
+// recomputeHeight resizes the input to fit its content,
+// between 1 line and the configured maximum.
+func (i *Input) recomputeHeight() {
+	lines := strings.Count(i.textarea.Value(), "\n") + 1
+	if lines > i.maxLines {
+		lines = i.maxLines
+	}
+	if lines != i.textarea.Height() {
+		i.textarea.SetHeight(lines)
+	}
+}
+
 // View renders the input.
 func (i Input) View() string {
 	inputStyle := lipgloss.NewStyle().
@@ -109,5 +173,36 @@ func (i Input) View() string {
 		Width(i.width).
 		MarginBottom(1)
 
-	return inputStyle.Render(i.textarea.View())
+	content := i.textarea.View()
+	if i.attachment != "" {
+		attachmentStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+		content = attachmentStyle.Render("Attached: "+i.attachment+"  (/attach to remove)") + "\n" + content
+	}
+	if counter := i.counterView(); counter != "" {
+		content += "\n" + counter
+	}
+	return inputStyle.Render(content)
+}
+
+// counterView renders the character counter, or "" while the input
+// is comfortably below the limit.
+func (i Input) counterView() string {
+	limit := i.textarea.CharLimit
+	if limit <= 0 {
+		return ""
+	}
+	length := i.Length()
+	if float64(length) < float64(limit)*counterShowRatio {
+		return ""
+	}
+
+	color := theme.TextSecondary
+	if float64(length) >= float64(limit)*counterWarnRatio {
+		color = theme.Warning
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Width(i.width).
+		Align(lipgloss.Right).
+		Render(fmt.Sprintf("%d/%d", length, limit))
 }
@@ -1,9 +1,11 @@
 package chat
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 
@@ -33,6 +35,62 @@ type Message struct {
 	Content   string
 	Timestamp time.Time
 	Streaming bool // True while response is being received
+
+	// SourceType is what /ask routed the message to ("assistant",
+	// "workflow", "module", ...), empty for direct replies
+	SourceType string
+
+	// Steps are the workflow steps behind the reply, if it came from a
+	// workflow run. Shown as a one-line summary unless expanded.
+	Steps         []Step
+	StepsExpanded bool
+
+	hasContent bool // Set once the first chunk arrives
+
+	md   *markdownCache // Last markdown render, shared across copies
+	view *viewCache     // Last full render, shared across copies
+}
+
+// viewCache remembers a message's last rendered output and what it was rendered from.
+type viewCache struct {
+	valid     bool
+	width     int
+	raw       bool
+	streaming bool
+	source    string
+	content   string
+	steps     int
+	expanded  bool
+	rendered  string
+}
+
+// Step is one step of a workflow run, as shown under its reply.
+type Step struct {
+	Name    string
+	Success bool
+	Error   string
+}
+
+// markdownCache remembers the last rendered markdown for a message
+// so unchanged content isn't re-rendered on every frame.
+type markdownCache struct {
+	source   string
+	width    int
+	rendered string
+}
+
+// render returns the markdown rendering of source, reusing the previous
+// result when neither the source nor the width changed.
+func (c *markdownCache) render(source string, width int) string {
+	if c == nil {
+		return renderMarkdown(source, width)
+	}
+	if c.rendered == "" || c.source != source || c.width != width {
+		c.source = source
+		c.width = width
+		c.rendered = renderMarkdown(source, width)
+	}
+	return c.rendered
 }
 
 // NewUserMessage creates a new user message.
@@ -41,6 +99,7 @@ func NewUserMessage(content string) Message {
 		Role:      RoleUser,
 		Content:   content,
 		Timestamp: time.Now(),
+		view:      &viewCache{},
 	}
 }
 
@@ -51,6 +110,8 @@ func NewHubMessage() Message {
 		Content:   "",
 		Timestamp: time.Now(),
 		Streaming: true,
+		md:        &markdownCache{},
+		view:      &viewCache{},
 	}
 }
 
@@ -60,17 +121,36 @@ func NewSystemMessage(content string) Message {
 		Role:      RoleSystem,
 		Content:   content,
 		Timestamp: time.Now(),
+		view:      &viewCache{},
 	}
 }
 
 // AppendContent adds content to the message (used for streaming).
 func (m *Message) AppendContent(chunk string) {
 	m.Content += chunk
+	if chunk != "" {
+		m.hasContent = true
+	}
+	m.invalidate()
+}
+
+// IsThinking reports whether the message is streaming but no content has
+// arrived yet.
+func (m Message) IsThinking() bool {
+	return m.Streaming && !m.hasContent
 }
 
 // FinishStreaming marks the message as complete.
 func (m *Message) FinishStreaming() {
 	m.Streaming = false
+	m.invalidate()
+}
+
+// invalidate forces the next View to re-render.
+func (m *Message) invalidate() {
+	if m.view != nil {
+		m.view.valid = false
+	}
 }
 
 // Message styles
@@ -92,12 +172,25 @@ var (
 	systemContentStyle = lipgloss.NewStyle().
 				Foreground(theme.TextSecondary)
 
+	hubRawStyle = lipgloss.NewStyle().
+			Foreground(theme.TextPrimary)
+
 	streamingStyle = lipgloss.NewStyle().
 			Foreground(theme.Warning)
+
+	stepsStyle = lipgloss.NewStyle().
+			Foreground(theme.TextSecondary)
+
+	stepOKStyle = lipgloss.NewStyle().
+			Foreground(theme.Success)
+
+	stepFailedStyle = lipgloss.NewStyle().
+			Foreground(theme.Error)
 )
 
-// Custom glamour style JSON - based on "dark" but with no left margin/indent
-var glamourStyle = []byte(`{
+// Custom glamour style JSON - based on "dark" but with no left margin/indent.
+// The placeholders are filled in from the active theme by glamourStyle.
+const glamourStyleTemplate = `{
 	"document": {
 		"block_prefix": "",
 		"block_suffix": "",
@@ -145,11 +238,11 @@ var glamourStyle = []byte(`{
 	"code_block": {
 		"margin": 0,
 		"chroma": {
-			"theme": "dracula"
+			"theme": "{{code_theme}}"
 		}
 	},
 	"code": {
-		"color": "203"
+		"color": "{{code}}"
 	},
 	"emph": {
 		"italic": true
@@ -158,22 +251,86 @@ var glamourStyle = []byte(`{
 		"bold": true
 	},
 	"link": {
-		"color": "39",
+		"color": "{{link}}",
 		"underline": true
 	},
 	"link_text": {
-		"color": "39",
+		"color": "{{link}}",
 		"bold": true
 	}
-}`)
+}`
 
-// renderMarkdown renders markdown content using glamour.
-// Uses a custom style with no left margin to fit our message layout.
-func renderMarkdown(content string, width int) string {
+// Default code highlighting themes for dark and light palettes
+const (
+	defaultDarkCodeTheme  = "dracula"
+	defaultLightCodeTheme = "github"
+)
+
+// codeTheme is the chroma theme used for code blocks ("" for the default).
+var codeTheme string
+
+// SetCodeTheme sets the chroma theme for code blocks. An empty name selects
+// the default for the terminal's background. Returns false and keeps the
+// default if the name isn't a known chroma theme.
+func SetCodeTheme(name string) bool {
+	valid := name == ""
+	if !valid {
+		_, valid = styles.Registry[name]
+	}
+	if valid {
+		codeTheme = name
+	} else {
+		codeTheme = ""
+	}
+	resetRenderers()
+	return valid
+}
+
+// glamourStyle builds the glamour style JSON from the active theme.
+func glamourStyle() []byte {
+	name := codeTheme
+	if name == "" {
+		name = defaultDarkCodeTheme
+		if !theme.Dark {
+			name = defaultLightCodeTheme
+		}
+	}
+	r := strings.NewReplacer(
+		"{{code_theme}}", name,
+		"{{code}}", string(theme.Warning),
+		"{{link}}", string(theme.Accent),
+	)
+	return []byte(r.Replace(glamourStyleTemplate))
+}
+
+// renderers caches glamour renderers by word-wrap width, since creating one is expensive.
+var renderers = map[int]*glamour.TermRenderer{}
+
+// rendererFor returns a cached glamour renderer for the given width.
+func rendererFor(width int) (*glamour.TermRenderer, error) {
+	if r, ok := renderers[width]; ok {
+		return r, nil
+	}
 	r, err := glamour.NewTermRenderer(
-		glamour.WithStylesFromJSONBytes(glamourStyle),
+		glamour.WithStylesFromJSONBytes(glamourStyle()),
 		glamour.WithWordWrap(width),
 	)
+	if err != nil {
+		return nil, err
+	}
+	renderers[width] = r
+	return r, nil
+}
+
+// resetRenderers drops all cached renderers, e.g. after the terminal is resized.
+func resetRenderers() {
+	renderers = map[int]*glamour.TermRenderer{}
+}
+
+// renderMarkdown renders markdown content using glamour.
+// Uses a custom style with no left margin to fit our message layout.
+func renderMarkdown(content string, width int) string {
+	r, err := rendererFor(width)
 	if err != nil {
 		return content
 	}
@@ -187,23 +344,54 @@ func renderMarkdown(content string, width int) string {
 	return strings.Trim(rendered, "\n")
 }
 
-// View renders the message.
-func (m Message) View(width int) string {
+// View renders the message. With raw set, hub messages show their markdown
+// source instead of rendering it.
+// The result is cached until the content, width, raw, streaming or step
+// state changes.
+func (m Message) View(width int, raw bool) string {
+	c := m.view
+	if c != nil && c.valid && c.width == width && c.raw == raw && c.streaming == m.Streaming &&
+		c.source == m.SourceType && c.content == m.Content &&
+		c.steps == len(m.Steps) && c.expanded == m.StepsExpanded {
+		return c.rendered
+	}
+
+	var rendered string
 	switch m.Role {
 	case RoleUser:
-		return m.renderUser(width)
+		rendered = m.renderUser(width)
 	case RoleHub:
-		return m.renderHub(width)
+		rendered = m.renderHub(width, raw)
 	case RoleSystem:
-		return m.renderSystem(width)
+		rendered = m.renderSystem(width)
 	}
-	return ""
+
+	if c != nil {
+		*c = viewCache{
+			valid:     true,
+			width:     width,
+			raw:       raw,
+			streaming: m.Streaming,
+			source:    m.SourceType,
+			content:   m.Content,
+			steps:     len(m.Steps),
+			expanded:  m.StepsExpanded,
+			rendered:  rendered,
+		}
+	}
+	return rendered
+}
+
+// contentWidth returns the width left for message text after the symbol
+// gutter, never negative.
+func contentWidth(width int) int {
+	return max(width-4, 0)
 }
 
 func (m Message) renderUser(width int) string {
 	symbol := userSymbolStyle.Render(UserSymbol)
 	content := userContentStyle.
-		Width(width - 4).
+		Width(contentWidth(width)).
 		Render(m.Content)
 
 	// Indent continuation lines
@@ -219,15 +407,38 @@ func (m Message) renderUser(width int) string {
 	return result.String()
 }
 
-func (m Message) renderHub(width int) string {
+func (m Message) renderHub(width int, raw bool) string {
 	symbol := hubSymbolStyle.Render(HubSymbol)
 
 	content := m.Content
-	if m.Streaming {
-		content += streamingStyle.Render(StreamingCursor)
+	if raw {
+		content = hubRawStyle.Width(contentWidth(width)).Render(content)
+		if m.Streaming {
+			content += streamingStyle.Render(StreamingCursor)
+		}
+	} else if m.Streaming {
+		// Render completed lines as markdown; the partial last line stays raw
+		// until its newline arrives, so re-rendering happens once per line.
+		complete, tail := splitPartialLine(content)
+		content = ""
+		if complete != "" {
+			content = m.md.render(closeOpenFence(complete), contentWidth(width))
+			if tail != "" {
+				content += "\n"
+			}
+		}
+		content += tail + streamingStyle.Render(StreamingCursor)
 	} else if content != "" {
-		// Render markdown only after streaming is complete
-		content = renderMarkdown(content, width-4)
+		content = m.md.render(content, contentWidth(width))
+	}
+
+	// Routed replies start with a badge naming what they came from
+	if badge := sourceBadge(m.SourceType); badge != "" {
+		content = badge + "\n" + content
+	}
+
+	if len(m.Steps) > 0 {
+		content += "\n" + m.renderSteps(contentWidth(width))
 	}
 
 	// Indent all content under the symbol
@@ -243,10 +454,106 @@ func (m Message) renderHub(width int) string {
 	return result.String()
 }
 
+// sourceBadges are the badge colors for each route type. Other types use
+// the muted text color.
+var sourceBadges = map[string]lipgloss.Color{
+	"assistant": theme.Accent,
+	"workflow":  theme.Warning,
+	"module":    theme.Success,
+}
+
+// sourceBadge returns a label like "[workflow]" for a routed reply, or ""
+// for replies from the hub itself.
+func sourceBadge(sourceType string) string {
+	if sourceType == "" || sourceType == "hub" {
+		return ""
+	}
+	color, ok := sourceBadges[sourceType]
+	if !ok {
+		color = theme.TextSecondary
+	}
+	return lipgloss.NewStyle().Foreground(color).Render("[" + sourceType + "]")
+}
+
+// renderSteps renders the workflow steps under a reply: a summary line
+// when collapsed, one line per step when expanded.
+func (m Message) renderSteps(width int) string {
+	failed := 0
+	for _, step := range m.Steps {
+		if !step.Success {
+			failed++
+		}
+	}
+	noun := "steps"
+	if len(m.Steps) == 1 {
+		noun = "step"
+	}
+	summary := fmt.Sprintf("%d %s", len(m.Steps), noun)
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+
+	if !m.StepsExpanded {
+		return stepsStyle.Render("▸ " + summary)
+	}
+
+	lines := []string{stepsStyle.Render("▾ " + summary)}
+	for _, step := range m.Steps {
+		mark := stepOKStyle.Render("✓")
+		line := step.Name
+		if !step.Success {
+			mark = stepFailedStyle.Render("✗")
+			if step.Error != "" {
+				line += ": " + step.Error
+			}
+		}
+		line = truncate(line, width-4)
+		lines = append(lines, "  "+mark+" "+stepsStyle.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncate shortens s to at most width runes, ending it with "…" if cut.
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(r[:max(width, 0)])
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// splitPartialLine splits content into its complete lines and the trailing
+// line that hasn't been terminated yet.
+func splitPartialLine(content string) (complete, tail string) {
+	i := strings.LastIndex(content, "\n")
+	if i < 0 {
+		return "", content
+	}
+	return content[:i], content[i+1:]
+}
+
+// closeOpenFence appends a closing code fence if content has an unclosed one,
+// so partial code blocks render as code instead of reflowing when they close.
+func closeOpenFence(content string) string {
+	open := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			open = !open
+		}
+	}
+	if open {
+		return content + "\n```"
+	}
+	return content
+}
+
 func (m Message) renderSystem(width int) string {
 	symbol := systemSymbolStyle.Render(SystemSymbol)
 	content := systemContentStyle.
-		Width(width - 4).
+		Width(contentWidth(width)).
 		Render(m.Content)
 
 	lines := strings.Split(content, "\n")
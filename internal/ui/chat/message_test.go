@@ -0,0 +1,123 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// transcriptSize is the number of messages in the benchmark transcript.
+const transcriptSize = 50
+
+// testTranscript returns n alternating user and hub messages, the hub
+// ones with enough markdown to exercise glamour.
+func testTranscript(n int) []Message {
+	msgs := make([]Message, 0, n)
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			msgs = append(msgs, NewUserMessage(fmt.Sprintf("Question %d: how do I rotate the logs?", i)))
+			continue
+		}
+		hub := NewHubMessage()
+		hub.AppendContent(fmt.Sprintf("## Answer %d\n\nRun the **rotate** workflow:\n\n"+
+			"```sh\nhub run rotate --keep 7\n```\n\n- keeps a week of logs\n- compresses the rest\n", i))
+		hub.FinishStreaming()
+		msgs = append(msgs, hub)
+	}
+	return msgs
+}
+
+// BenchmarkRenderTranscript renders the markdown of a transcript, with
+// renderers shared per width versus built for every message.
+func BenchmarkRenderTranscript(b *testing.B) {
+	msgs := testTranscript(transcriptSize)
+	const width = 80
+
+	b.Run("shared renderer", func(b *testing.B) {
+		resetRenderers()
+		for i := 0; i < b.N; i++ {
+			for _, msg := range msgs {
+				renderMarkdown(msg.Content, width)
+			}
+		}
+	})
+	b.Run("fresh renderer", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, msg := range msgs {
+				resetRenderers()
+				renderMarkdown(msg.Content, width)
+			}
+		}
+	})
+}
+
+// BenchmarkMessageView renders every message of a transcript, as a frame
+// does, with and without the per-message view cache.
+func BenchmarkMessageView(b *testing.B) {
+	cached := testTranscript(transcriptSize)
+	uncached := make([]Message, len(cached))
+	for i, msg := range cached {
+		// No view or markdown cache, so every View renders from scratch
+		uncached[i] = Message{Role: msg.Role, Content: msg.Content, Timestamp: msg.Timestamp}
+	}
+	const width = 80
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, msg := range cached {
+				msg.View(width, false)
+			}
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, msg := range uncached {
+				msg.View(width, false)
+			}
+		}
+	})
+}
+
+func TestMessageViewCacheInvalidation(t *testing.T) {
+	msg := NewHubMessage()
+	msg.AppendContent("first chunk")
+	if got := msg.View(80, false); !strings.Contains(got, "first chunk") {
+		t.Fatalf("View = %q, want the first chunk", got)
+	}
+
+	// Appending content re-renders
+	msg.AppendContent(" second chunk")
+	if msg.view.valid {
+		t.Error("cache still valid after AppendContent")
+	}
+	if got := msg.View(80, false); !strings.Contains(got, "second chunk") {
+		t.Errorf("View after AppendContent = %q, want the second chunk", got)
+	}
+
+	// Finishing the stream re-renders
+	msg.FinishStreaming()
+	if msg.view.valid {
+		t.Error("cache still valid after FinishStreaming")
+	}
+	msg.View(80, false)
+	if msg.view.streaming {
+		t.Error("cached view still rendered as streaming")
+	}
+
+	// A cached render is reused at the same width
+	first := msg.View(80, false)
+	if again := msg.View(80, false); again != first {
+		t.Errorf("View changed without any change to the message")
+	}
+
+	// A new width re-renders
+	msg.AppendContent(" " + strings.Repeat("word ", 30))
+	wide := msg.View(80, false)
+	narrow := msg.View(30, false)
+	if msg.view.width != 30 {
+		t.Errorf("cached width = %d, want 30", msg.view.width)
+	}
+	if strings.Count(narrow, "\n") <= strings.Count(wide, "\n") {
+		t.Errorf("narrow render has no more lines than wide:\n%s\n---\n%s", narrow, wide)
+	}
+}
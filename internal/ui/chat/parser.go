@@ -25,11 +25,18 @@ var KnownCommands = []string{
 	"help",
 	"hub",
 	"refresh",
+	"reconnect",
 	"modules",
 	"integrations",
 	"workflows",
 	"tasks",
+	"rerun",
+	"export-run",
 	"settings",
+	"model",
+	"whoami",
+	"attach",
+	"raw",
 }
 
 // DetectPrefix returns the prefix type and the text after the prefix.
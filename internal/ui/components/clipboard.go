@@ -0,0 +1,18 @@
+package components
+
+import (
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ClipboardCopiedMsg is sent when a clipboard copy completes.
+type ClipboardCopiedMsg struct {
+	Error error
+}
+
+// CopyToClipboard returns a command that copies text to the system clipboard.
+func CopyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		return ClipboardCopiedMsg{Error: clipboard.WriteAll(text)}
+	}
+}
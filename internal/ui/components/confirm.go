@@ -32,8 +32,11 @@ func NewConfirmation() *Confirmation {
 }
 
 // WithTimeout sets a custom timeout duration.
+// Non-positive durations keep the default.
 func (c *Confirmation) WithTimeout(d time.Duration) *Confirmation {
-	c.timeout = d
+	if d > 0 {
+		c.timeout = d
+	}
 	return c
 }
 
@@ -1,7 +1,11 @@
 package components
 
 import (
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,11 +17,12 @@ import (
 type FieldType int
 
 const (
-	FieldText     FieldType = iota // Text input field
-	FieldSelect                    // Selection field with options
-	FieldButton                    // Button (e.g., Save, Cancel)
-	FieldCheckbox                  // Checkbox (toggle with space or enter)
-	FieldTextArea                  // Multi-line text input field
+	FieldText        FieldType = iota // Text input field
+	FieldSelect                       // Selection field with options
+	FieldButton                       // Button (e.g., Save, Cancel)
+	FieldCheckbox                     // Checkbox (toggle with space or enter)
+	FieldTextArea                     // Multi-line text input field
+	FieldMultiSelect                  // Options toggled with space; Value is comma-joined
 )
 
 // FormField represents a single form field.
@@ -37,6 +42,12 @@ type FormField struct {
 	Error       string // Validation error to display below field
 	Description string // Help text shown below field
 	ParamType   string // Original param type: "string", "number", "boolean", "array", "object"
+
+	// Default is the value Ctrl+D restores, "true" or "false" for
+	// checkboxes. NewForm fills it from the initial value when empty.
+	Default string
+
+	initial string // Value when the form was created, for IsDirty
 }
 
 // Form is a reusable form component.
@@ -45,12 +56,26 @@ type Form struct {
 	Fields  []FormField
 	focused int
 	cursor  int // Cursor position in current field (text fields only)
+
+	// Horizontal scrolling of the focused text field
+	width       int // Available width; 0 disables scrolling
+	offset      int // First visible rune
+	offsetField int // Field the offset belongs to
+
+	dirty bool // Set by MarkDirty
 }
 
 // NewForm creates a new form with the given title and fields.
 func NewForm(title string, fields []FormField) *Form {
-	// Initialize select field selected index based on Value
 	for i := range fields {
+		// Remember the initial value, and use it as the default unless one
+		// was given
+		fields[i].initial = fields[i].state()
+		if fields[i].Default == "" {
+			fields[i].Default = fields[i].initial
+		}
+
+		// Initialize select field selected index based on Value
 		if fields[i].Type == FieldSelect && fields[i].Value != "" {
 			for j, opt := range fields[i].Options {
 				if opt == fields[i].Value {
@@ -71,9 +96,16 @@ func NewForm(title string, fields []FormField) *Form {
 func (f *Form) Update(msg tea.KeyMsg) bool {
 	field := &f.Fields[f.focused]
 
+	if msg.String() == "ctrl+d" {
+		f.ResetField(field.Key)
+		return false
+	}
+
 	switch field.Type {
 	case FieldSelect:
 		return f.updateSelect(msg)
+	case FieldMultiSelect:
+		return f.updateMultiSelect(msg)
 	case FieldButton:
 		return f.updateButton(msg)
 	case FieldCheckbox:
@@ -90,50 +122,60 @@ func (f *Form) updateText(msg tea.KeyMsg) bool {
 	switch msg.Type {
 	case tea.KeyTab, tea.KeyEnter:
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyShiftTab:
 		f.focused = (f.focused - 1 + len(f.Fields)) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyUp:
 		f.focused = (f.focused - 1 + len(f.Fields)) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyDown:
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyLeft:
-		if f.cursor > 0 {
+		if msg.Alt {
+			f.cursor = wordStart(f.Fields[f.focused].Value, f.cursor)
+		} else if f.cursor > 0 {
 			f.cursor--
 		}
 	case tea.KeyRight:
-		if f.cursor < len(f.Fields[f.focused].Value) {
+		if msg.Alt {
+			f.cursor = wordEnd(f.Fields[f.focused].Value, f.cursor)
+		} else if f.cursor < runeLen(f.Fields[f.focused].Value) {
 			f.cursor++
 		}
+	case tea.KeyCtrlW:
+		// Delete the previous word
+		val := f.Fields[f.focused].Value
+		start := wordStart(val, f.cursor)
+		f.Fields[f.focused].Value = deleteRange(val, start, f.cursor)
+		f.cursor = start
 	case tea.KeyHome, tea.KeyCtrlA:
 		f.cursor = 0
 	case tea.KeyEnd, tea.KeyCtrlE:
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyBackspace:
 		if f.cursor > 0 {
 			val := f.Fields[f.focused].Value
-			f.Fields[f.focused].Value = val[:f.cursor-1] + val[f.cursor:]
+			f.Fields[f.focused].Value = deleteRange(val, f.cursor-1, f.cursor)
 			f.cursor--
 		}
 	case tea.KeyDelete:
 		val := f.Fields[f.focused].Value
-		if f.cursor < len(val) {
-			f.Fields[f.focused].Value = val[:f.cursor] + val[f.cursor+1:]
+		if f.cursor < runeLen(val) {
+			f.Fields[f.focused].Value = deleteRange(val, f.cursor, f.cursor+1)
 		}
 	case tea.KeySpace:
 		// Insert space at cursor position
 		val := f.Fields[f.focused].Value
-		f.Fields[f.focused].Value = val[:f.cursor] + " " + val[f.cursor:]
+		f.Fields[f.focused].Value = insertAt(val, f.cursor, " ")
 		f.cursor++
 	case tea.KeyRunes:
 		// Insert runes at cursor position (handles both typing and paste)
 		text := string(msg.Runes)
 		val := f.Fields[f.focused].Value
-		f.Fields[f.focused].Value = val[:f.cursor] + text + val[f.cursor:]
-		f.cursor += len(text)
+		f.Fields[f.focused].Value = insertAt(val, f.cursor, text)
+		f.cursor += runeLen(text)
 	}
 	return false
 }
@@ -143,13 +185,13 @@ func (f *Form) updateButton(msg tea.KeyMsg) bool {
 	switch msg.Type {
 	case tea.KeyTab:
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyShiftTab, tea.KeyUp:
 		f.focused = (f.focused - 1 + len(f.Fields)) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyDown:
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyEnter:
 		return true
 	}
@@ -163,13 +205,13 @@ func (f *Form) updateCheckbox(msg tea.KeyMsg) bool {
 	switch msg.Type {
 	case tea.KeyTab:
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyShiftTab, tea.KeyUp:
 		f.focused = (f.focused - 1 + len(f.Fields)) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyDown:
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeySpace, tea.KeyEnter:
 		field.Checked = !field.Checked
 	}
@@ -184,10 +226,10 @@ func (f *Form) updateSelect(msg tea.KeyMsg) bool {
 	case tea.KeyTab, tea.KeyEnter:
 		// Move to next field (don't submit)
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyShiftTab:
 		f.focused = (f.focused - 1 + len(f.Fields)) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyUp, tea.KeyLeft:
 		// Navigate options up
 		if len(field.Options) > 0 {
@@ -218,6 +260,87 @@ func (f *Form) updateSelect(msg tea.KeyMsg) bool {
 	return false
 }
 
+// updateMultiSelect handles input for multi-select fields.
+// Up/Down move the highlight and Space toggles the highlighted option.
+func (f *Form) updateMultiSelect(msg tea.KeyMsg) bool {
+	field := &f.Fields[f.focused]
+
+	switch msg.Type {
+	case tea.KeyTab, tea.KeyEnter:
+		f.focused = (f.focused + 1) % len(f.Fields)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
+	case tea.KeyShiftTab:
+		f.focused = (f.focused - 1 + len(f.Fields)) % len(f.Fields)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
+	case tea.KeyUp:
+		if len(field.Options) > 0 {
+			field.Selected = (field.Selected - 1 + len(field.Options)) % len(field.Options)
+		}
+	case tea.KeyDown:
+		if len(field.Options) > 0 {
+			field.Selected = (field.Selected + 1) % len(field.Options)
+		}
+	case tea.KeySpace:
+		toggleOption(field)
+	case tea.KeyRunes:
+		switch msg.String() {
+		case "k":
+			if len(field.Options) > 0 {
+				field.Selected = (field.Selected - 1 + len(field.Options)) % len(field.Options)
+			}
+		case "j":
+			if len(field.Options) > 0 {
+				field.Selected = (field.Selected + 1) % len(field.Options)
+			}
+		case " ":
+			toggleOption(field)
+		}
+	}
+	return false
+}
+
+// toggleOption checks or unchecks the highlighted option of a multi-select
+// field, keeping Value in option order.
+func toggleOption(field *FormField) {
+	if field.Selected < 0 || field.Selected >= len(field.Options) {
+		return
+	}
+	opt := field.Options[field.Selected]
+	if field.DisabledOptions[opt] {
+		return
+	}
+	checked := selectedSet(field.Value)
+	checked[opt] = !checked[opt]
+
+	var values []string
+	for _, o := range field.Options {
+		if checked[o] {
+			values = append(values, o)
+		}
+	}
+	field.Value = strings.Join(values, ",")
+}
+
+// splitSelected parses a multi-select Value into its checked options.
+func splitSelected(value string) []string {
+	var selected []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			selected = append(selected, v)
+		}
+	}
+	return selected
+}
+
+// selectedSet returns the checked options of a multi-select Value as a set.
+func selectedSet(value string) map[string]bool {
+	set := make(map[string]bool)
+	for _, v := range splitSelected(value) {
+		set[v] = true
+	}
+	return set
+}
+
 // updateTextArea handles input for multi-line text area fields.
 // Enter inserts newlines, Tab/Shift+Tab moves between fields.
 func (f *Form) updateTextArea(msg tea.KeyMsg) bool {
@@ -226,14 +349,14 @@ func (f *Form) updateTextArea(msg tea.KeyMsg) bool {
 	switch msg.Type {
 	case tea.KeyTab:
 		f.focused = (f.focused + 1) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyShiftTab:
 		f.focused = (f.focused - 1 + len(f.Fields)) % len(f.Fields)
-		f.cursor = len(f.Fields[f.focused].Value)
+		f.cursor = runeLen(f.Fields[f.focused].Value)
 	case tea.KeyEnter:
 		// Insert newline at cursor position
 		val := field.Value
-		field.Value = val[:f.cursor] + "\n" + val[f.cursor:]
+		field.Value = insertAt(val, f.cursor, "\n")
 		f.cursor++
 	case tea.KeyUp:
 		// Move cursor up one line
@@ -246,7 +369,7 @@ func (f *Form) updateTextArea(msg tea.KeyMsg) bool {
 			f.cursor--
 		}
 	case tea.KeyRight:
-		if f.cursor < len(field.Value) {
+		if f.cursor < runeLen(field.Value) {
 			f.cursor++
 		}
 	case tea.KeyHome, tea.KeyCtrlA:
@@ -258,29 +381,136 @@ func (f *Form) updateTextArea(msg tea.KeyMsg) bool {
 	case tea.KeyBackspace:
 		if f.cursor > 0 {
 			val := field.Value
-			field.Value = val[:f.cursor-1] + val[f.cursor:]
+			field.Value = deleteRange(val, f.cursor-1, f.cursor)
 			f.cursor--
 		}
 	case tea.KeyDelete:
 		val := field.Value
-		if f.cursor < len(val) {
-			field.Value = val[:f.cursor] + val[f.cursor+1:]
+		if f.cursor < runeLen(val) {
+			field.Value = deleteRange(val, f.cursor, f.cursor+1)
 		}
 	case tea.KeySpace:
 		// Insert space at cursor position
 		val := field.Value
-		field.Value = val[:f.cursor] + " " + val[f.cursor:]
+		field.Value = insertAt(val, f.cursor, " ")
 		f.cursor++
 	case tea.KeyRunes:
 		// Insert runes at cursor position (handles both typing and paste)
 		text := string(msg.Runes)
 		val := field.Value
-		field.Value = val[:f.cursor] + text + val[f.cursor:]
-		f.cursor += len(text)
+		field.Value = insertAt(val, f.cursor, text)
+		f.cursor += runeLen(text)
 	}
 	return false
 }
 
+// runeLen returns the length of s in runes, the unit of the form cursor.
+func runeLen(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// insertAt inserts text into s before rune index i.
+func insertAt(s string, i int, text string) string {
+	r := []rune(s)
+	return string(r[:i]) + text + string(r[i:])
+}
+
+// deleteRange removes runes [from, to) from s.
+func deleteRange(s string, from, to int) string {
+	r := []rune(s)
+	return string(r[:from]) + string(r[to:])
+}
+
+// wordStart returns the start of the whitespace-delimited word before
+// cursor, skipping any whitespace immediately before it.
+func wordStart(val string, cursor int) int {
+	r := []rune(val)
+	i := cursor
+	for i > 0 && unicode.IsSpace(r[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(r[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the whitespace-delimited word after cursor,
+// skipping any whitespace immediately after it.
+func wordEnd(val string, cursor int) int {
+	r := []rune(val)
+	i := cursor
+	for i < len(r) && unicode.IsSpace(r[i]) {
+		i++
+	}
+	for i < len(r) && !unicode.IsSpace(r[i]) {
+		i++
+	}
+	return i
+}
+
+// splitAtCursor splits s around the rune at cursor. At the end of s,
+// the cursor rune is empty.
+func splitAtCursor(s string, cursor int) (before, at, after string) {
+	r := []rune(s)
+	if cursor >= len(r) {
+		return s, "", ""
+	}
+	return string(r[:cursor]), string(r[cursor]), string(r[cursor+1:])
+}
+
+// scrollWindow returns the part of the focused field's value that fits in
+// width columns, the cursor position within it, and whether text is hidden
+// to the left or right. The window only moves when the cursor leaves it.
+func (f *Form) scrollWindow(val string, width int) (string, int, bool, bool) {
+	if f.offsetField != f.focused {
+		f.offsetField = f.focused
+		f.offset = 0
+	}
+	// +1 for the cursor cell past the end
+	if width <= 0 || lipgloss.Width(val)+1 <= width {
+		f.offset = 0
+		return val, f.cursor, false, false
+	}
+
+	r := []rune(val)
+	cursor := min(f.cursor, len(r))
+	inner := max(width-2, 1) // Room for a … marker on each side
+
+	// Slide left to the cursor, or right until the cursor cell fits
+	f.offset = min(f.offset, cursor)
+	for f.offset < cursor && lipgloss.Width(string(r[f.offset:cursor]))+1 > inner {
+		f.offset++
+	}
+	// Don't leave space unused at the end after deleting
+	for f.offset > 0 && lipgloss.Width(string(r[f.offset-1:]))+1 <= inner {
+		f.offset--
+	}
+
+	end, used := f.offset, 0
+	for end < len(r) {
+		w := lipgloss.Width(string(r[end]))
+		if used+w > inner {
+			break
+		}
+		used += w
+		end++
+	}
+	return string(r[f.offset:end]), cursor - f.offset, f.offset > 0, end < len(r)
+}
+
+// truncateWidth cuts s to at most width display columns.
+func truncateWidth(s string, width int) string {
+	w := 0
+	for i, r := range s {
+		w += lipgloss.Width(string(r))
+		if w > width {
+			return s[:i]
+		}
+	}
+	return s
+}
+
 // moveCursorVertical moves the cursor up or down by the given number of lines.
 func (f *Form) moveCursorVertical(field *FormField, direction int) {
 	lines := strings.Split(field.Value, "\n")
@@ -293,7 +523,7 @@ func (f *Form) moveCursorVertical(field *FormField, direction int) {
 	currentCol := f.cursor
 	pos := 0
 	for i, line := range lines {
-		lineLen := len(line)
+		lineLen := runeLen(line)
 		if i < len(lines)-1 {
 			lineLen++ // Account for newline
 		}
@@ -316,14 +546,14 @@ func (f *Form) moveCursorVertical(field *FormField, direction int) {
 
 	// Calculate new cursor position
 	newCol := currentCol
-	if newCol > len(lines[newLine]) {
-		newCol = len(lines[newLine])
+	if newCol > runeLen(lines[newLine]) {
+		newCol = runeLen(lines[newLine])
 	}
 
 	// Calculate absolute position
 	newPos := 0
 	for i := 0; i < newLine; i++ {
-		newPos += len(lines[i]) + 1 // +1 for newline
+		newPos += runeLen(lines[i]) + 1 // +1 for newline
 	}
 	newPos += newCol
 	f.cursor = newPos
@@ -331,8 +561,9 @@ func (f *Form) moveCursorVertical(field *FormField, direction int) {
 
 // findLineStart finds the start position of the line containing the cursor.
 func (f *Form) findLineStart(value string, cursor int) int {
+	r := []rune(value)
 	for i := cursor - 1; i >= 0; i-- {
-		if value[i] == '\n' {
+		if r[i] == '\n' {
 			return i + 1
 		}
 	}
@@ -341,18 +572,20 @@ func (f *Form) findLineStart(value string, cursor int) int {
 
 // findLineEnd finds the end position of the line containing the cursor.
 func (f *Form) findLineEnd(value string, cursor int) int {
-	for i := cursor; i < len(value); i++ {
-		if value[i] == '\n' {
+	r := []rune(value)
+	for i := cursor; i < len(r); i++ {
+		if r[i] == '\n' {
 			return i
 		}
 	}
-	return len(value)
+	return len(r)
 }
 
 // SetFieldOptions updates the options for a select field and resets selection.
 func (f *Form) SetFieldOptions(key string, options []string, defaultValue string) {
 	for i := range f.Fields {
 		if f.Fields[i].Key == key {
+			changed := f.Fields[i].state() != f.Fields[i].initial
 			f.Fields[i].Options = options
 			f.Fields[i].Selected = 0
 			f.Fields[i].Value = ""
@@ -368,6 +601,14 @@ func (f *Form) SetFieldOptions(key string, options []string, defaultValue string
 			if f.Fields[i].Value == "" && len(options) > 0 {
 				f.Fields[i].Value = options[0]
 			}
+			// Loading options isn't an edit
+			if !changed {
+				f.Fields[i].initial = f.Fields[i].Value
+			}
+			// A reset can only restore one of the new options
+			if !slices.Contains(options, f.Fields[i].Default) {
+				f.Fields[i].Default = f.Fields[i].Value
+			}
 			break
 		}
 	}
@@ -393,6 +634,16 @@ func (f *Form) GetFieldChecked(key string) bool {
 	return false
 }
 
+// GetFieldSelected returns the checked options of a multi-select field.
+func (f *Form) GetFieldSelected(key string) []string {
+	for _, field := range f.Fields {
+		if field.Key == key {
+			return splitSelected(field.Value)
+		}
+	}
+	return nil
+}
+
 // SetFieldDisabledOptions sets which options are disabled for a select field.
 func (f *Form) SetFieldDisabledOptions(key string, disabled map[string]bool) {
 	for i := range f.Fields {
@@ -416,6 +667,100 @@ func (f *Form) IsSelectedDisabled(key string) bool {
 	return false
 }
 
+// ResetField restores the field with the given key to its default value.
+func (f *Form) ResetField(key string) {
+	for i := range f.Fields {
+		field := &f.Fields[i]
+		if field.Key != key {
+			continue
+		}
+		switch field.Type {
+		case FieldButton:
+			return
+		case FieldCheckbox:
+			field.Checked = field.Default == "true"
+		case FieldSelect:
+			field.Value = field.Default
+			for j, opt := range field.Options {
+				if opt == field.Value {
+					field.Selected = j
+					break
+				}
+			}
+		default:
+			field.Value = field.Default
+		}
+		if i == f.focused {
+			f.cursor = runeLen(field.Value)
+		}
+		return
+	}
+}
+
+// state returns the field's value as a string: Value, or "true" or
+// "false" for checkboxes.
+func (field FormField) state() string {
+	if field.Type == FieldCheckbox {
+		return strconv.FormatBool(field.Checked)
+	}
+	return field.Value
+}
+
+// isDefault reports whether the field still holds its default value.
+func (field FormField) isDefault() bool {
+	return field.state() == field.Default
+}
+
+// IsDirty reports whether any field was changed since the form was
+// created, or MarkDirty was called.
+func (f *Form) IsDirty() bool {
+	if f.dirty {
+		return true
+	}
+	for _, field := range f.Fields {
+		if field.Type != FieldButton && field.state() != field.initial {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkDirty makes IsDirty report true, e.g. for a form rebuilt from one
+// that had changes.
+func (f *Form) MarkDirty() {
+	f.dirty = true
+}
+
+// defaultHint returns a line noting the field's default when its value
+// differs from it, or "" if there's nothing to show. Password fields never
+// show theirs.
+func (f *Form) defaultHint(field FormField, style lipgloss.Style) string {
+	if field.Password || field.Type == FieldButton || field.isDefault() {
+		return ""
+	}
+	def := field.Default
+	switch field.Type {
+	case FieldCheckbox:
+		def = "off"
+		if field.Default == "true" {
+			def = "on"
+		}
+	case FieldMultiSelect:
+		def = strings.Join(splitSelected(def), ", ")
+	}
+	if def == "" {
+		def = "(empty)"
+	}
+	if first, _, multiline := strings.Cut(def, "\n"); multiline {
+		def = first + "…"
+	}
+	hint := "(default: " + def + ")  [Ctrl+D] Reset"
+	if f.width > 0 {
+		hint = truncateWidth(hint, max(f.width-4, 1))
+	}
+	return "    " + style.Render(hint)
+}
+
 // IsFieldFocused returns true if the field with the given key is currently focused.
 func (f *Form) IsFieldFocused(key string) bool {
 	if f.focused < 0 || f.focused >= len(f.Fields) {
@@ -482,6 +827,12 @@ func (f *Form) SetFieldValue(key, value string) {
 	}
 }
 
+// SetWidth sets the available width, so text values wider than it scroll
+// to keep the cursor in view. Zero disables scrolling.
+func (f *Form) SetWidth(width int) {
+	f.width = width
+}
+
 // View renders the form.
 func (f *Form) View() string {
 	var lines []string
@@ -501,7 +852,7 @@ func (f *Form) View() string {
 		isFocused := i == f.focused
 
 		switch field.Type {
-		case FieldSelect:
+		case FieldSelect, FieldMultiSelect:
 			lines = append(lines, f.renderSelectField(field, isFocused, labelStyle, valueStyle, focusedValueStyle, optionStyle, selectedOptionStyle, disabledStyle)...)
 		case FieldButton:
 			lines = append(lines, f.renderButtonField(field, isFocused, focusedValueStyle, labelStyle))
@@ -530,16 +881,28 @@ func (f *Form) renderTextField(field FormField, isFocused bool, labelStyle, valu
 
 	val := field.Value
 	if field.Password && val != "" {
-		val = strings.Repeat("*", len(val))
+		val = strings.Repeat("*", runeLen(val))
+	}
+
+	// Fit the value beside the label, keeping the cursor in view
+	width := 0
+	if f.width > 0 {
+		width = max(f.width-lipgloss.Width("  "+label+" "), 1)
+	}
+	cursor := f.cursor
+	var clippedLeft, clippedRight bool
+	if isFocused {
+		val, cursor, clippedLeft, clippedRight = f.scrollWindow(val, width)
+	} else if width > 0 && lipgloss.Width(val) > width {
+		val = truncateWidth(val, width-1)
+		clippedRight = true
 	}
 
 	var renderedValue string
 	if isFocused {
 		// Show cursor
-		if f.cursor < len(val) {
-			before := val[:f.cursor]
-			cursorChar := string(val[f.cursor])
-			after := val[f.cursor+1:]
+		if cursor < runeLen(val) {
+			before, cursorChar, after := splitAtCursor(val, cursor)
 			if field.Password {
 				cursorChar = "*"
 			}
@@ -555,6 +918,12 @@ func (f *Form) renderTextField(field FormField, isFocused bool, labelStyle, valu
 			renderedValue = labelStyle.Render("(empty)")
 		}
 	}
+	if clippedLeft {
+		renderedValue = labelStyle.Render("…") + renderedValue
+	}
+	if clippedRight {
+		renderedValue += labelStyle.Render("…")
+	}
 
 	lines = append(lines, "  "+label+" "+renderedValue)
 
@@ -563,6 +932,10 @@ func (f *Form) renderTextField(field FormField, isFocused bool, labelStyle, valu
 		lines = append(lines, "    "+errorStyle.Render("! "+field.Error))
 	}
 
+	if hint := f.defaultHint(field, descStyle); hint != "" {
+		lines = append(lines, hint)
+	}
+
 	// Show description if focused and no error
 	if isFocused && field.Description != "" && field.Error == "" {
 		lines = append(lines, "    "+descStyle.Render(field.Description))
@@ -580,9 +953,17 @@ func (f *Form) renderSelectField(field FormField, isFocused bool, labelStyle, va
 	// Check if current value is disabled
 	isDisabled := field.DisabledOptions != nil && field.DisabledOptions[field.Value]
 
+	multi := field.Type == FieldMultiSelect
+	if multi {
+		isDisabled = false // Value lists several options
+	}
+
 	if !isFocused {
 		// When not focused, show label and current value on one line
 		val := field.Value
+		if multi {
+			val = strings.Join(splitSelected(field.Value), ", ")
+		}
 		if val == "" {
 			val = "(none)"
 		}
@@ -598,12 +979,20 @@ func (f *Form) renderSelectField(field FormField, isFocused bool, labelStyle, va
 		if len(field.Options) == 0 {
 			lines = append(lines, "    "+optionStyle.Render("(no options available)"))
 		} else {
+			checked := selectedSet(field.Value)
 			for j, opt := range field.Options {
 				optDisabled := field.DisabledOptions != nil && field.DisabledOptions[opt]
 				displayOpt := opt
 				if optDisabled {
 					displayOpt = opt + " (not configured)"
 				}
+				if multi {
+					mark := "[ ] "
+					if checked[opt] {
+						mark = "[✓] "
+					}
+					displayOpt = mark + displayOpt
+				}
 
 				if j == field.Selected {
 					if optDisabled {
@@ -615,13 +1004,17 @@ func (f *Form) renderSelectField(field FormField, isFocused bool, labelStyle, va
 					if optDisabled {
 						lines = append(lines, "      "+disabledStyle.Render(displayOpt))
 					} else {
-						lines = append(lines, "      "+optionStyle.Render(opt))
+						lines = append(lines, "      "+optionStyle.Render(displayOpt))
 					}
 				}
 			}
 		}
 	}
 
+	if hint := f.defaultHint(field, optionStyle.Faint(true)); hint != "" {
+		lines = append(lines, hint)
+	}
+
 	return lines
 }
 
@@ -663,6 +1056,10 @@ func (f *Form) renderCheckboxField(field FormField, isFocused bool, labelStyle,
 		lines = append(lines, "    "+errorStyle.Render("! "+field.Error))
 	}
 
+	if hint := f.defaultHint(field, labelStyle.Faint(true)); hint != "" {
+		lines = append(lines, hint)
+	}
+
 	return lines
 }
 
@@ -689,15 +1086,15 @@ func (f *Form) renderTextAreaField(field FormField, isFocused bool, labelStyle,
 	cursorCol := f.cursor
 	pos := 0
 	for i, line := range textLines {
-		lineLen := len(line)
+		lineLen := runeLen(line)
 		if i < len(textLines)-1 {
 			lineLen++ // Account for newline
 		}
 		if pos+lineLen > f.cursor || i == len(textLines)-1 {
 			cursorLine = i
 			cursorCol = f.cursor - pos
-			if cursorCol > len(line) {
-				cursorCol = len(line)
+			if cursorCol > runeLen(line) {
+				cursorCol = runeLen(line)
 			}
 			break
 		}
@@ -723,21 +1120,13 @@ func (f *Form) renderTextAreaField(field FormField, isFocused bool, labelStyle,
 
 	// Render visible lines
 	for i := startLine; i < endLine; i++ {
-		line := textLines[i]
-		if len(line) > 38 {
-			line = line[:38]
-		}
+		line := truncateWidth(textLines[i], 38)
 
 		var renderedLine string
 		if isFocused && i == cursorLine {
 			// Show cursor on this line
-			if cursorCol < len(line) {
-				before := line[:cursorCol]
-				cursorChar := string(line[cursorCol])
-				after := ""
-				if cursorCol+1 < len(line) {
-					after = line[cursorCol+1:]
-				}
+			if cursorCol < runeLen(line) {
+				before, cursorChar, after := splitAtCursor(line, cursorCol)
 				renderedLine = focusedValueStyle.Render(before) +
 					cursorStyle.Render(cursorChar) +
 					focusedValueStyle.Render(after)
@@ -777,6 +1166,10 @@ func (f *Form) renderTextAreaField(field FormField, isFocused bool, labelStyle,
 		lines = append(lines, "    "+errorStyle.Render("! "+field.Error))
 	}
 
+	if hint := f.defaultHint(field, descStyle); hint != "" {
+		lines = append(lines, hint)
+	}
+
 	// Show description
 	if field.Description != "" && field.Error == "" {
 		lines = append(lines, "    "+descStyle.Render(field.Description))
@@ -0,0 +1,128 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func typeText(f *Form, s string) {
+	for _, r := range s {
+		f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func pressKey(f *Form, t tea.KeyType, n int) {
+	for i := 0; i < n; i++ {
+		f.Update(tea.KeyMsg{Type: t})
+	}
+}
+
+func TestFormMultibyteEditing(t *testing.T) {
+	tests := []struct {
+		name  string
+		typed string
+		left  int    // Left presses before editing
+		want  string // Value after backspace then typing "x"
+		wantC int    // Cursor after the edit
+	}{
+		{"accented at end", "café", 0, "cafx", 4},
+		{"accented in middle", "café", 2, "cxfé", 2},
+		{"cjk at end", "日本語", 0, "日本x", 3},
+		{"cjk in middle", "日本語", 1, "日x語", 2},
+		{"cjk at start", "日本語", 3, "x日本語", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewForm("Test", []FormField{{Label: "Name", Key: "name"}})
+			typeText(f, tt.typed)
+			if got := f.Fields[0].Value; got != tt.typed {
+				t.Fatalf("typed value = %q, want %q", got, tt.typed)
+			}
+			if f.cursor != runeLen(tt.typed) {
+				t.Fatalf("cursor after typing = %d, want %d", f.cursor, runeLen(tt.typed))
+			}
+
+			pressKey(f, tea.KeyLeft, tt.left)
+			pressKey(f, tea.KeyBackspace, 1)
+			typeText(f, "x")
+			if got := f.Fields[0].Value; got != tt.want {
+				t.Errorf("value = %q, want %q", got, tt.want)
+			}
+			if f.cursor != tt.wantC {
+				t.Errorf("cursor = %d, want %d", f.cursor, tt.wantC)
+			}
+		})
+	}
+}
+
+func TestFormCursorBounds(t *testing.T) {
+	f := NewForm("Test", []FormField{{Label: "Name", Key: "name"}})
+	typeText(f, "日本語")
+	pressKey(f, tea.KeyRight, 5)
+	if f.cursor != 3 {
+		t.Errorf("cursor past end = %d, want 3", f.cursor)
+	}
+	pressKey(f, tea.KeyLeft, 5)
+	if f.cursor != 0 {
+		t.Errorf("cursor before start = %d, want 0", f.cursor)
+	}
+	pressKey(f, tea.KeyDelete, 1)
+	if got := f.Fields[0].Value; got != "本語" {
+		t.Errorf("value after delete = %q, want %q", got, "本語")
+	}
+}
+
+func TestFormScrollWindowMultibyte(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		width     int
+		left      int // Left presses from the end
+		wantLeft  bool
+		wantRight bool
+	}{
+		{"accented fits", "café", 10, 0, false, false},
+		{"cjk fits", "日本語", 10, 0, false, false},
+		{"accented at end", "café café café", 8, 0, true, false},
+		{"accented at start", "café café café", 8, 14, false, true},
+		{"cjk at end", "日本語日本語日本語", 8, 0, true, false},
+		{"cjk at start", "日本語日本語日本語", 8, 9, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewForm("Test", []FormField{{Label: "Name", Key: "name"}})
+			typeText(f, tt.value)
+			pressKey(f, tea.KeyLeft, tt.left)
+
+			window, cursor, left, right := f.scrollWindow(tt.value, tt.width)
+			if left != tt.wantLeft || right != tt.wantRight {
+				t.Errorf("clipped left/right = %v/%v, want %v/%v", left, right, tt.wantLeft, tt.wantRight)
+			}
+			if cursor < 0 || cursor > runeLen(window) {
+				t.Fatalf("cursor %d outside window %q", cursor, window)
+			}
+			// The window fits, leaving room for … markers when clipped,
+			// and a cursor past the end gets a cell of its own
+			limit := tt.width
+			if left || right {
+				limit = tt.width - 2
+			}
+			used := lipgloss.Width(window)
+			if cursor == runeLen(window) {
+				used++
+			}
+			if used > limit {
+				t.Errorf("window %q with cursor at %d needs %d columns, want at most %d", window, cursor, used, limit)
+			}
+			// The rune under the cursor is the one the form's cursor is on
+			r := []rune(tt.value)
+			if f.cursor < len(r) {
+				if got := []rune(window)[cursor]; got != r[f.cursor] {
+					t.Errorf("rune at window cursor = %q, want %q", got, r[f.cursor])
+				}
+			}
+		})
+	}
+}
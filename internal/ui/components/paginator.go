@@ -0,0 +1,58 @@
+package components
+
+// Paginator tracks the position in a cursor-paginated list. Pages are
+// numbered from 0. The cursor for each page is remembered as it's
+// discovered, so earlier pages can be loaded again when going back.
+type Paginator struct {
+	page    int
+	hasMore bool
+	cursors []string // cursors[i] loads page i; page 0 needs no cursor
+}
+
+// NewPaginator creates a paginator positioned before the first page.
+func NewPaginator() *Paginator {
+	return &Paginator{cursors: []string{""}}
+}
+
+// Reset returns to the first page and forgets every cursor.
+func (p *Paginator) Reset() {
+	*p = Paginator{cursors: []string{""}}
+}
+
+// Page returns the current page.
+func (p *Paginator) Page() int {
+	return p.page
+}
+
+// HasNext reports whether there is a page after the current one.
+func (p *Paginator) HasNext() bool {
+	return p.hasMore && p.page+1 < len(p.cursors)
+}
+
+// HasPrev reports whether there is a page before the current one.
+func (p *Paginator) HasPrev() bool {
+	return p.page > 0
+}
+
+// Cursor returns the cursor that loads page, or "" if it isn't known.
+func (p *Paginator) Cursor(page int) string {
+	if page < 0 || page >= len(p.cursors) {
+		return ""
+	}
+	return p.cursors[page]
+}
+
+// Loaded moves to page once it has loaded, recording whether more pages
+// follow and the cursor for the next one. Call it only when the load
+// succeeds, so a failed load leaves the position unchanged.
+func (p *Paginator) Loaded(page int, hasMore bool, nextCursor string) {
+	if page < 0 || page >= len(p.cursors) {
+		return
+	}
+	p.page = page
+	p.hasMore = hasMore && nextCursor != ""
+	p.cursors = p.cursors[:page+1]
+	if p.hasMore {
+		p.cursors = append(p.cursors, nextCursor)
+	}
+}
@@ -0,0 +1,55 @@
+package components
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pxp/hub-tui/internal/ui/theme"
+)
+
+// spinnerInterval is the delay between spinner frames.
+const spinnerInterval = 100 * time.Millisecond
+
+// spinnerFrames are the braille dot frames cycled by the spinner.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// SpinnerTickMsg advances spinners by one frame.
+// This is a shared message type broadcast to whichever component is loading.
+type SpinnerTickMsg struct{}
+
+// SpinnerTick returns a command that emits the next SpinnerTickMsg.
+func SpinnerTick() tea.Cmd {
+	return tea.Tick(spinnerInterval, func(t time.Time) tea.Msg {
+		return SpinnerTickMsg{}
+	})
+}
+
+// Spinner is a small animated loading indicator.
+// Embed this in modals and advance it on SpinnerTickMsg.
+type Spinner struct {
+	frame int
+}
+
+// NewSpinner creates a new spinner at its first frame.
+func NewSpinner() *Spinner {
+	return &Spinner{}
+}
+
+// Advance moves the spinner to its next frame.
+func (s *Spinner) Advance() {
+	s.frame = (s.frame + 1) % len(spinnerFrames)
+}
+
+// Frame returns the current spinner glyph.
+func (s *Spinner) Frame() string {
+	return spinnerFrames[s.frame]
+}
+
+// View renders the spinner followed by a label in the muted text color.
+func (s *Spinner) View(label string) string {
+	frameStyle := lipgloss.NewStyle().Foreground(theme.Accent)
+	labelStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	return frameStyle.Render(s.Frame()) + " " + labelStyle.Render(label)
+}
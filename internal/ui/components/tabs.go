@@ -0,0 +1,68 @@
+package components
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pxp/hub-tui/internal/ui/theme"
+)
+
+// Tabs is a row of labelled tabs for switching between a modal's sub-views.
+// Left/Right move between tabs.
+type Tabs struct {
+	labels   []string
+	selected int
+}
+
+// NewTabs creates tabs with the given labels, the first one selected.
+func NewTabs(labels ...string) *Tabs {
+	return &Tabs{labels: labels}
+}
+
+// Selected returns the index of the selected tab.
+func (t *Tabs) Selected() int {
+	return t.selected
+}
+
+// SetSelected selects the tab at index i, ignoring out-of-range indexes.
+func (t *Tabs) SetSelected(i int) {
+	if i >= 0 && i < len(t.labels) {
+		t.selected = i
+	}
+}
+
+// Update handles tab navigation keys.
+// Returns true if the selected tab changed.
+func (t *Tabs) Update(msg tea.KeyMsg) bool {
+	if len(t.labels) == 0 {
+		return false
+	}
+	prev := t.selected
+	switch msg.String() {
+	case "left":
+		t.selected = (t.selected - 1 + len(t.labels)) % len(t.labels)
+	case "right":
+		t.selected = (t.selected + 1) % len(t.labels)
+	}
+	return t.selected != prev
+}
+
+// View renders the tab header, e.g. "Profiles │ Providers" with the
+// selected tab highlighted.
+func (t *Tabs) View() string {
+	selectedStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Underline(true)
+	normalStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	sepStyle := lipgloss.NewStyle().Foreground(theme.Surface)
+
+	parts := make([]string, len(t.labels))
+	for i, label := range t.labels {
+		if i == t.selected {
+			parts[i] = selectedStyle.Render(label)
+		} else {
+			parts[i] = normalStyle.Render(label)
+		}
+	}
+	return strings.Join(parts, sepStyle.Render(" │ "))
+}
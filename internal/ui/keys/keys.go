@@ -0,0 +1,216 @@
+// Package keys maps user actions to key bindings, with defaults that can
+// be overridden from the config.
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Action names a bindable action. The names are the keys of the
+// "keybindings" config option.
+type Action string
+
+// Global actions
+const (
+	Quit       Action = "quit"
+	Redraw     Action = "redraw"
+	Cancel     Action = "cancel"
+	Regenerate Action = "regenerate"
+	Refresh    Action = "refresh"
+	Attention  Action = "attention"
+)
+
+// Chat actions
+const (
+	Newline      Action = "newline"
+	ScrollUp     Action = "scroll_up"
+	ScrollDown   Action = "scroll_down"
+	PageUp       Action = "page_up"
+	PageDown     Action = "page_down"
+	ScrollTop    Action = "scroll_top"
+	ScrollBottom Action = "scroll_bottom"
+	PrevMessage  Action = "prev_message"
+	NextMessage  Action = "next_message"
+	ToggleSteps  Action = "toggle_steps"
+)
+
+// Modal actions. These can share keys with chat actions, since the chat
+// doesn't get keys while a modal is open.
+const (
+	ListUp     Action = "list_up"
+	ListDown   Action = "list_down"
+	Delete     Action = "delete"
+	SetDefault Action = "set_default"
+	Test       Action = "test"
+	TestForm   Action = "test_form"
+	Save       Action = "save"
+)
+
+// defaults are the bindings used for actions the config doesn't override.
+// Keys use Bubble Tea's names, as returned by tea.KeyMsg.String.
+var defaults = map[Action][]string{
+	Quit:         {"ctrl+c"},
+	Redraw:       {"ctrl+l"},
+	Cancel:       {"esc"},
+	Regenerate:   {"ctrl+r"},
+	Refresh:      {"f5"},
+	Attention:    {"ctrl+g"},
+	Newline:      {"ctrl+j", "alt+enter"},
+	ScrollUp:     {"up"},
+	ScrollDown:   {"down"},
+	PageUp:       {"pgup"},
+	PageDown:     {"pgdown"},
+	ScrollTop:    {"home"},
+	ScrollBottom: {"end"},
+	PrevMessage:  {"ctrl+p"},
+	NextMessage:  {"ctrl+n"},
+	ToggleSteps:  {"ctrl+o"},
+	ListUp:       {"k", "up"},
+	ListDown:     {"j", "down"},
+	Delete:       {"d"},
+	SetDefault:   {"s"},
+	Test:         {"t"},
+	TestForm:     {"ctrl+t"},
+	Save:         {"ctrl+s"},
+}
+
+// scope is where an action's keys are handled.
+type scope int
+
+const (
+	scopeGlobal scope = iota
+	scopeChat
+	scopeModal
+)
+
+// scopeOf returns where action's keys are handled.
+func scopeOf(action Action) scope {
+	switch action {
+	case Quit, Redraw, Cancel, Regenerate, Refresh, Attention:
+		return scopeGlobal
+	case ListUp, ListDown, Delete, SetDefault, Test, TestForm, Save:
+		return scopeModal
+	}
+	return scopeChat
+}
+
+// overlaps reports whether keys in scopes a and b can be pressed at the
+// same time.
+func overlaps(a, b scope) bool {
+	return a == b || a == scopeGlobal || b == scopeGlobal
+}
+
+// Keymap resolves key presses to actions.
+type Keymap struct {
+	bindings map[Action][]string
+}
+
+// Default returns the keymap with no overrides.
+func Default() *Keymap {
+	k, _ := New(nil)
+	return k
+}
+
+// New returns the default keymap with overrides applied. Each override maps
+// an action name to one or more comma-separated keys, e.g.
+// {"quit": "ctrl+q", "prev_message": "ctrl+up,["}.
+// Unknown actions, empty bindings and keys bound to more than one action
+// handled at the same time are errors.
+func New(overrides map[string]string) (*Keymap, error) {
+	bindings := make(map[Action][]string, len(defaults))
+	for action, keys := range defaults {
+		bindings[action] = keys
+	}
+
+	for name, value := range overrides {
+		action := Action(name)
+		if _, ok := defaults[action]; !ok {
+			return nil, fmt.Errorf("unknown action %q", name)
+		}
+		var keys []string
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("no keys bound to %q", name)
+		}
+		bindings[action] = keys
+	}
+
+	// Check in a fixed order so the same config always reports the same conflict
+	actions := make([]Action, 0, len(bindings))
+	for action := range bindings {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+
+	owners := make(map[string][]Action)
+	for _, action := range actions {
+		for _, key := range bindings[action] {
+			for _, other := range owners[key] {
+				if other != action && overlaps(scopeOf(other), scopeOf(action)) {
+					return nil, fmt.Errorf("%s is bound to both %q and %q", key, other, action)
+				}
+			}
+			owners[key] = append(owners[key], action)
+		}
+	}
+
+	return &Keymap{bindings: bindings}, nil
+}
+
+// Is reports whether msg is bound to action.
+func (k *Keymap) Is(msg tea.KeyMsg, action Action) bool {
+	key := msg.String()
+	for _, bound := range k.bindings[action] {
+		if bound == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the first key bound to action for display, e.g. "Ctrl+C".
+func (k *Keymap) Label(action Action) string {
+	keys := k.bindings[action]
+	if len(keys) == 0 {
+		return ""
+	}
+	return label(keys[0])
+}
+
+// IsText reports whether msg would otherwise type a character, so bindings
+// like "[" should only act when there's no text being edited.
+func IsText(msg tea.KeyMsg) bool {
+	return utf8.RuneCountInString(msg.String()) == 1
+}
+
+// keyLabels are display names for keys that aren't simply capitalized.
+var keyLabels = map[string]string{
+	"up":     "↑",
+	"down":   "↓",
+	"left":   "←",
+	"right":  "→",
+	"pgup":   "PgUp",
+	"pgdown": "PgDn",
+}
+
+// label formats a key name for display, e.g. "ctrl+c" as "Ctrl+C".
+func label(key string) string {
+	parts := strings.Split(key, "+")
+	for i, part := range parts {
+		if l, ok := keyLabels[part]; ok {
+			parts[i] = l
+		} else if part != "" && (utf8.RuneCountInString(part) > 1 || len(parts) > 1) {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,60 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   string
+	}{
+		{"defaults", nil, ""},
+		{"chat and modal share a key", map[string]string{"delete": "ctrl+p"}, ""},
+		{"two modal actions", map[string]string{"delete": "s"}, `"delete" and "set_default"`},
+		{"two chat actions", map[string]string{"next_message": "ctrl+p"}, `"next_message" and "prev_message"`},
+		{"global and modal", map[string]string{"save": "ctrl+l"}, `"redraw" and "save"`},
+		{"global and chat", map[string]string{"toggle_steps": "f5"}, `"refresh" and "toggle_steps"`},
+		{"unknown action", map[string]string{"launch": "x"}, `unknown action "launch"`},
+		{"no keys", map[string]string{"save": " , "}, `no keys bound to "save"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.overrides)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("New: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New error = %v, want it to mention %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModalBindings(t *testing.T) {
+	k, err := New(map[string]string{"list_down": "n,down", "save": "ctrl+w"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	if !k.Is(runes("n"), ListDown) || !k.Is(tea.KeyMsg{Type: tea.KeyDown}, ListDown) {
+		t.Error("list_down override not applied")
+	}
+	if k.Is(runes("j"), ListDown) {
+		t.Error("j still moves down after list_down was rebound")
+	}
+	if !k.Is(runes("k"), ListUp) {
+		t.Error("k no longer moves up")
+	}
+	if got := k.Label(Save); got != "Ctrl+W" {
+		t.Errorf("save label = %q, want Ctrl+W", got)
+	}
+}
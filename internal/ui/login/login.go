@@ -1,12 +1,14 @@
 package login
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pxp/hub-tui/internal/client"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
@@ -17,6 +19,7 @@ const (
 	FieldServerURL Field = iota
 	FieldUsername
 	FieldPassword
+	FieldToken
 )
 
 // State represents the login state.
@@ -39,9 +42,18 @@ type Model struct {
 	serverURL    textinput.Model
 	username     textinput.Model
 	password     textinput.Model
+	token        textinput.Model
+	tokenMode    bool // Log in with an API token instead of credentials
 
 	// NeedsServerURL indicates if we need to prompt for server URL.
 	NeedsServerURL bool
+
+	// Previously used server URLs, cycled with Ctrl+N/Ctrl+P
+	recentServers []string
+	recentIdx     int // Index into recentServers, -1 when none is selected
+
+	// Label of the quit key ("" for Ctrl+C)
+	quitKey string
 }
 
 // New creates a new login model.
@@ -80,12 +92,24 @@ func New(needsServerURL bool, defaultServerURL string) Model {
 	password.TextStyle = textStyle
 	password.PlaceholderStyle = placeholderStyle
 
+	token := textinput.New()
+	token.Placeholder = "API token"
+	token.CharLimit = 4096
+	token.Width = 35
+	token.EchoMode = textinput.EchoPassword
+	token.EchoCharacter = '*'
+	token.PromptStyle = promptStyle
+	token.TextStyle = textStyle
+	token.PlaceholderStyle = placeholderStyle
+
 	m := Model{
 		NeedsServerURL: needsServerURL,
 		serverURL:      serverURL,
 		username:       username,
 		password:       password,
+		token:          token,
 		state:          StateInput,
+		recentIdx:      -1,
 	}
 
 	// Focus the first relevant field
@@ -106,6 +130,49 @@ func (m *Model) SetSize(width, height int) {
 	m.height = height
 }
 
+// SetRecentServers sets previously used server URLs to offer, most recent first.
+func (m *Model) SetRecentServers(servers []string) {
+	m.recentServers = servers
+	m.recentIdx = -1
+}
+
+// urlEditable reports whether the server URL field is shown: when no
+// server is configured yet, or when there are recent servers to pick from.
+func (m Model) urlEditable() bool {
+	return m.NeedsServerURL || len(m.recentServers) > 0
+}
+
+// cycleRecentServer fills the server URL field with the next (or previous)
+// recent server.
+func (m *Model) cycleRecentServer(delta int) {
+	n := len(m.recentServers)
+	if n == 0 {
+		return
+	}
+	if m.recentIdx < 0 {
+		// Start from the entered URL if it's in the history, otherwise
+		// from the most recent (forward) or oldest (backward) entry
+		m.recentIdx = -1
+		for i, s := range m.recentServers {
+			if s == m.ServerURL() {
+				m.recentIdx = i
+				break
+			}
+		}
+		if m.recentIdx < 0 {
+			if delta > 0 {
+				m.recentIdx = 0
+			} else {
+				m.recentIdx = n - 1
+			}
+			delta = 0
+		}
+	}
+	m.recentIdx = ((m.recentIdx+delta)%n + n) % n
+	m.serverURL.SetValue(m.recentServers[m.recentIdx])
+	m.serverURL.CursorEnd()
+}
+
 // SetError sets an error message to display.
 func (m *Model) SetError(err string) {
 	m.error = err
@@ -123,14 +190,23 @@ func (m *Model) Reset() {
 	m.error = ""
 }
 
+// SetQuitKey sets the quit key label shown in the hint, e.g. "Ctrl+Q".
+func (m *Model) SetQuitKey(label string) {
+	m.quitKey = label
+}
+
 // SetCtrlCPressed sets the Ctrl+C pressed state for the quit hint.
 func (m *Model) SetCtrlCPressed(pressed bool) {
 	m.ctrlCPressed = pressed
 }
 
-// ServerURL returns the entered server URL.
+// ServerURL returns the entered server URL, normalized when it is valid.
 func (m Model) ServerURL() string {
-	return strings.TrimSpace(m.serverURL.Value())
+	raw := strings.TrimSpace(m.serverURL.Value())
+	if normalized, err := client.NormalizeServerURL(raw); err == nil {
+		return normalized
+	}
+	return raw
 }
 
 // Username returns the entered username.
@@ -143,6 +219,43 @@ func (m Model) Password() string {
 	return m.password.Value()
 }
 
+// TokenMode returns true if the form is in API token mode.
+func (m Model) TokenMode() bool {
+	return m.tokenMode
+}
+
+// Token returns the entered API token.
+func (m Model) Token() string {
+	return strings.TrimSpace(m.token.Value())
+}
+
+// toggleTokenMode switches between credentials and API token entry.
+func (m *Model) toggleTokenMode() {
+	m.blurCurrent()
+	m.tokenMode = !m.tokenMode
+	if m.focused != FieldServerURL {
+		// Focus the first field of the new mode
+		if m.tokenMode {
+			m.focused = FieldToken
+		} else {
+			m.focused = FieldUsername
+		}
+	}
+	m.focusCurrent()
+}
+
+// fields returns the visible fields in tab order.
+func (m Model) fields() []Field {
+	var fields []Field
+	if m.urlEditable() {
+		fields = append(fields, FieldServerURL)
+	}
+	if m.tokenMode {
+		return append(fields, FieldToken)
+	}
+	return append(fields, FieldUsername, FieldPassword)
+}
+
 // Update handles input events.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if m.state == StateConnecting {
@@ -158,8 +271,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case "shift+tab", "up":
 			m.prevField()
 			return m, nil
+		case "ctrl+n", "ctrl+p":
+			if m.focused == FieldServerURL {
+				if msg.String() == "ctrl+n" {
+					m.cycleRecentServer(1)
+				} else {
+					m.cycleRecentServer(-1)
+				}
+				return m, nil
+			}
+		case "ctrl+t":
+			m.toggleTokenMode()
+			return m, nil
 		case "enter":
-			if m.focused == FieldPassword {
+			if m.isLastField() {
 				// Submit form
 				return m, nil
 			}
@@ -177,6 +302,8 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.username, cmd = m.username.Update(msg)
 	case FieldPassword:
 		m.password, cmd = m.password.Update(msg)
+	case FieldToken:
+		m.token, cmd = m.token.Update(msg)
 	}
 
 	// Clear error on input
@@ -189,50 +316,25 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func (m *Model) nextField() {
-	m.blurCurrent()
-
-	if m.NeedsServerURL {
-		switch m.focused {
-		case FieldServerURL:
-			m.focused = FieldUsername
-		case FieldUsername:
-			m.focused = FieldPassword
-		case FieldPassword:
-			m.focused = FieldServerURL
-		}
-	} else {
-		switch m.focused {
-		case FieldUsername:
-			m.focused = FieldPassword
-		case FieldPassword:
-			m.focused = FieldUsername
-		}
-	}
-
-	m.focusCurrent()
+	m.moveFocus(1)
 }
 
 func (m *Model) prevField() {
-	m.blurCurrent()
+	m.moveFocus(-1)
+}
 
-	if m.NeedsServerURL {
-		switch m.focused {
-		case FieldServerURL:
-			m.focused = FieldPassword
-		case FieldUsername:
-			m.focused = FieldServerURL
-		case FieldPassword:
-			m.focused = FieldUsername
-		}
-	} else {
-		switch m.focused {
-		case FieldUsername:
-			m.focused = FieldPassword
-		case FieldPassword:
-			m.focused = FieldUsername
+// moveFocus moves focus by delta fields, wrapping around.
+func (m *Model) moveFocus(delta int) {
+	fields := m.fields()
+	idx := 0
+	for i, f := range fields {
+		if f == m.focused {
+			idx = i
 		}
 	}
 
+	m.blurCurrent()
+	m.focused = fields[((idx+delta)%len(fields)+len(fields))%len(fields)]
 	m.focusCurrent()
 }
 
@@ -244,6 +346,8 @@ func (m *Model) blurCurrent() {
 		m.username.Blur()
 	case FieldPassword:
 		m.password.Blur()
+	case FieldToken:
+		m.token.Blur()
 	}
 }
 
@@ -255,6 +359,8 @@ func (m *Model) focusCurrent() {
 		m.username.Focus()
 	case FieldPassword:
 		m.password.Focus()
+	case FieldToken:
+		m.token.Focus()
 	}
 }
 
@@ -272,19 +378,37 @@ func (m Model) View() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
-	// Server URL field (if needed)
-	if m.NeedsServerURL {
+	// Server URL field (if needed or there are recent servers)
+	if m.urlEditable() {
 		b.WriteString(m.renderField("Server URL", m.serverURL.View(), m.focused == FieldServerURL))
 		b.WriteString("\n")
+		if m.focused == FieldServerURL && len(m.recentServers) > 0 {
+			hint := "Ctrl+N/P: recent servers"
+			if m.recentIdx >= 0 {
+				hint += fmt.Sprintf(" (%d/%d)", m.recentIdx+1, len(m.recentServers))
+			}
+			b.WriteString(lipgloss.NewStyle().
+				Foreground(theme.TextSecondary).
+				Italic(true).
+				PaddingLeft(13).
+				Render(hint))
+			b.WriteString("\n")
+		}
 	}
 
-	// Username field
-	b.WriteString(m.renderField("Username", m.username.View(), m.focused == FieldUsername))
-	b.WriteString("\n")
+	if m.tokenMode {
+		// API token field
+		b.WriteString(m.renderField("API token", m.token.View(), m.focused == FieldToken))
+		b.WriteString("\n")
+	} else {
+		// Username field
+		b.WriteString(m.renderField("Username", m.username.View(), m.focused == FieldUsername))
+		b.WriteString("\n")
 
-	// Password field
-	b.WriteString(m.renderField("Password", m.password.View(), m.focused == FieldPassword))
-	b.WriteString("\n")
+		// Password field
+		b.WriteString(m.renderField("Password", m.password.View(), m.focused == FieldPassword))
+		b.WriteString("\n")
+	}
 
 	// State message
 	switch m.state {
@@ -306,7 +430,7 @@ func (m Model) View() string {
 		hint := lipgloss.NewStyle().
 			Foreground(theme.TextSecondary).
 			Italic(true).
-			Render("Press Enter to connect")
+			Render(m.connectHint())
 		b.WriteString("\n")
 		b.WriteString(hint)
 	}
@@ -320,16 +444,20 @@ func (m Model) View() string {
 	form := formStyle.Render(b.String())
 
 	// Ctrl+C hint below the form
+	quitKey := m.quitKey
+	if quitKey == "" {
+		quitKey = "Ctrl+C"
+	}
 	var quitHint string
 	if m.ctrlCPressed {
 		quitHint = lipgloss.NewStyle().
 			Foreground(theme.Warning).
-			Render("Ctrl+C again to quit")
+			Render(quitKey + " again to quit")
 	} else {
 		quitHint = lipgloss.NewStyle().
 			Foreground(theme.TextSecondary).
 			Italic(true).
-			Render("Press Ctrl+C twice to quit")
+			Render("Press " + quitKey + " twice to quit")
 	}
 
 	// Join with newline instead of JoinVertical to avoid padding
@@ -356,15 +484,40 @@ func (m Model) renderField(label, input string, focused bool) string {
 	return labelStyle.Render(label+":") + " " + input
 }
 
-// IsSubmit checks if the Enter key was pressed on the password field.
+// connectHint returns the hint shown below the form.
+func (m Model) connectHint() string {
+	if m.tokenMode {
+		return "Press Enter to connect  ·  Ctrl+T: use password"
+	}
+	return "Press Enter to connect  ·  Ctrl+T: use API token"
+}
+
+// isLastField returns true if the last field of the form is focused.
+func (m Model) isLastField() bool {
+	fields := m.fields()
+	return m.focused == fields[len(fields)-1]
+}
+
+// IsSubmit checks if the Enter key was pressed on the last field.
 func (m Model) IsSubmit(msg tea.KeyMsg) bool {
-	return msg.String() == "enter" && m.focused == FieldPassword
+	return msg.String() == "enter" && m.isLastField()
 }
 
 // Validate checks if the form has valid input.
 func (m Model) Validate() string {
-	if m.NeedsServerURL && m.ServerURL() == "" {
-		return "Server URL is required"
+	if m.urlEditable() {
+		if m.ServerURL() == "" {
+			return "Server URL is required"
+		}
+		if _, err := client.NormalizeServerURL(m.ServerURL()); err != nil {
+			return "Invalid server URL (e.g. http://192.168.1.100:8787)"
+		}
+	}
+	if m.tokenMode {
+		if m.Token() == "" {
+			return "API token is required"
+		}
+		return ""
 	}
 	if m.Username() == "" {
 		return "Username is required"
@@ -0,0 +1,49 @@
+package login
+
+import "testing"
+
+func TestCycleRecentServer(t *testing.T) {
+	recent := []string{"http://a:8787", "http://b:8787", "http://c:8787"}
+	tests := []struct {
+		name   string
+		url    string
+		deltas []int
+		want   string
+	}{
+		{"next from unknown URL", "", []int{1}, "http://a:8787"},
+		{"previous from unknown URL", "", []int{-1}, "http://c:8787"},
+		{"next from current URL", "http://a:8787", []int{1}, "http://b:8787"},
+		{"previous from current URL", "http://b:8787", []int{-1}, "http://a:8787"},
+		{"wraps forward", "", []int{1, 1, 1, 1}, "http://a:8787"},
+		{"wraps backward", "", []int{-1, -1, -1, -1}, "http://c:8787"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(false, tt.url)
+			m.SetRecentServers(recent)
+			for _, d := range tt.deltas {
+				m.cycleRecentServer(d)
+			}
+			if got := m.ServerURL(); got != tt.want {
+				t.Errorf("server URL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerURLEditableWithHistory(t *testing.T) {
+	m := New(false, "http://a:8787")
+	if m.urlEditable() {
+		t.Error("URL field editable without history on re-login")
+	}
+	m.SetRecentServers([]string{"http://a:8787"})
+	if !m.urlEditable() {
+		t.Error("URL field not editable with history")
+	}
+	if got := m.fields()[0]; got != FieldServerURL {
+		t.Errorf("first field = %v, want FieldServerURL", got)
+	}
+	if m.focused != FieldUsername {
+		t.Errorf("focused = %v, want FieldUsername", m.focused)
+	}
+}
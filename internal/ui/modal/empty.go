@@ -0,0 +1,20 @@
+package modal
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pxp/hub-tui/internal/ui/theme"
+)
+
+// emptyState renders the message shown when a list has nothing in it,
+// followed by a hint on what to do about it.
+func emptyState(title, hint string) string {
+	titleStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render(title),
+		"",
+		hintStyle.Render(hint),
+	)
+}
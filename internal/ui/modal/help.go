@@ -6,20 +6,46 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pxp/hub-tui/internal/ui/chat"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
+// commandDescriptions maps slash commands to their help text.
+// Commands in chat.KnownCommands without an entry are listed without a description.
+var commandDescriptions = map[string]string{
+	"hub":          "Return to hub context",
+	"modules":      "Manage modules",
+	"integrations": "Configure integrations",
+	"workflows":    "Browse workflows",
+	"tasks":        "View tasks",
+	"rerun":        "Run the last workflow again",
+	"export-run":   "Save a run as JSON: /export-run <id>",
+	"settings":     "Settings",
+	"help":         "This help",
+	"clear":        "Clear chat",
+	"refresh":      "Refresh cache and connection",
+	"reconnect":    "Retry the connection to the server",
+	"exit":         "Exit",
+	"model":        "List or set default LLM profile",
+	"whoami":       "Show the logged-in user",
+	"attach":       "Attach a file to the next message",
+	"raw":          "Toggle raw markdown in replies",
+}
+
 // HelpModal displays command and keyboard reference.
 type HelpModal struct {
 	scroll int
 	height int
+	keys   *keys.Keymap
 }
 
-// NewHelpModal creates a new help modal.
-func NewHelpModal() *HelpModal {
+// NewHelpModal creates a new help modal listing the bindings in k.
+func NewHelpModal(k *keys.Keymap) *HelpModal {
 	return &HelpModal{
 		scroll: 0,
 		height: 14, // Visible lines
+		keys:   k,
 	}
 }
 
@@ -32,14 +58,14 @@ func (m *HelpModal) Init() tea.Cmd {
 func (m *HelpModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case msg.String() == "esc":
 			return nil, nil // Close modal
-		case "up", "k":
+		case m.keys.Is(msg, keys.ListUp):
 			if m.scroll > 0 {
 				m.scroll--
 			}
-		case "down", "j":
+		case m.keys.Is(msg, keys.ListDown):
 			maxScroll := m.contentLen() - m.height
 			if maxScroll < 0 {
 				maxScroll = 0
@@ -52,9 +78,17 @@ func (m *HelpModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	return m, nil
 }
 
+// SetHeight sets the number of visible lines.
+func (m *HelpModal) SetHeight(height int) {
+	m.height = height
+	if m.height < minScrollHeight {
+		m.height = minScrollHeight
+	}
+}
+
 // contentLen returns the number of lines in the help content.
 func (m *HelpModal) contentLen() int {
-	return 27 // Update this if content changes
+	return len(m.content())
 }
 
 // Title returns the modal title.
@@ -62,8 +96,8 @@ func (m *HelpModal) Title() string {
 	return "Help"
 }
 
-// View renders the help content.
-func (m *HelpModal) View() string {
+// content builds the full list of help lines.
+func (m *HelpModal) content() []string {
 	headerStyle := lipgloss.NewStyle().
 		Foreground(theme.Accent).
 		Bold(true)
@@ -74,34 +108,63 @@ func (m *HelpModal) View() string {
 	descStyle := lipgloss.NewStyle().
 		Foreground(theme.TextSecondary)
 
+	// Pad command names to a common column
+	nameWidth := len("{assistant}")
+	for _, name := range chat.KnownCommands {
+		if len(name) > nameWidth {
+			nameWidth = len(name)
+		}
+	}
+	pad := func(s string) string {
+		return s + strings.Repeat(" ", nameWidth-len(s))
+	}
+
 	content := []string{
 		headerStyle.Render("Commands"),
 		"",
-		cmdStyle.Render("  @{assistant}") + descStyle.Render("  Switch to assistant"),
-		cmdStyle.Render("  #{workflow} ") + descStyle.Render("  Run workflow"),
+		cmdStyle.Render("  @"+pad("{assistant}")) + descStyle.Render("  Switch to assistant"),
+		cmdStyle.Render("  #"+pad("{workflow}")) + descStyle.Render("  Run workflow"),
 		"",
-		cmdStyle.Render("  /hub        ") + descStyle.Render("  Return to hub context"),
-		cmdStyle.Render("  /modules    ") + descStyle.Render("  Manage modules"),
-		cmdStyle.Render("  /integrations") + descStyle.Render(" Configure integrations"),
-		cmdStyle.Render("  /workflows  ") + descStyle.Render("  Browse workflows"),
-		cmdStyle.Render("  /tasks      ") + descStyle.Render("  View tasks"),
-		cmdStyle.Render("  /settings   ") + descStyle.Render("  Settings"),
-		cmdStyle.Render("  /help       ") + descStyle.Render("  This help"),
-		cmdStyle.Render("  /clear      ") + descStyle.Render("  Clear chat"),
-		cmdStyle.Render("  /refresh    ") + descStyle.Render("  Refresh cache"),
-		cmdStyle.Render("  /exit       ") + descStyle.Render("  Exit"),
+	}
+
+	for _, name := range chat.KnownCommands {
+		content = append(content, cmdStyle.Render("  /"+pad(name))+descStyle.Render("  "+commandDescriptions[name]))
+	}
+
+	content = append(content,
 		"",
 		headerStyle.Render("Keyboard"),
 		"",
-		cmdStyle.Render("  Enter    ") + descStyle.Render("  Send / Select"),
-		cmdStyle.Render("  Ctrl+J   ") + descStyle.Render("  New line"),
-		cmdStyle.Render("  Tab      ") + descStyle.Render("  Autocomplete"),
-		cmdStyle.Render("  Ctrl+C   ") + descStyle.Render("  Exit (×2)"),
-		cmdStyle.Render("  Esc      ") + descStyle.Render("  Back / Cancel"),
-		cmdStyle.Render("  q        ") + descStyle.Render("  Close modal"),
-		cmdStyle.Render("  j/k      ") + descStyle.Render("  Navigate lists"),
-		cmdStyle.Render("  ↑/↓      ") + descStyle.Render("  Scroll chat"),
-	}
+		keyRow("Enter", "Send / Select"),
+		keyRow(m.keys.Label(keys.Newline), "New line"),
+		keyRow(m.keys.Label(keys.Regenerate), "Regenerate reply"),
+		keyRow(m.keys.Label(keys.Refresh), "Refresh"),
+		keyRow(m.keys.Label(keys.Attention), "Tasks needing attention"),
+		keyRow("Tab", "Autocomplete"),
+		keyRow("Ctrl+D", "Reset form field to default"),
+		keyRow(m.keys.Label(keys.Quit), "Exit (×2)"),
+		keyRow(m.keys.Label(keys.Cancel), "Back / Cancel"),
+		keyRow("q", "Close modal"),
+		keyRow(m.keys.Label(keys.ListDown)+"/"+m.keys.Label(keys.ListUp), "Navigate lists"),
+		keyRow(m.keys.Label(keys.ScrollUp)+"/"+m.keys.Label(keys.ScrollDown), "Scroll chat"),
+		keyRow(m.keys.Label(keys.PrevMessage)+", "+m.keys.Label(keys.NextMessage), "Previous/next message"),
+		keyRow(m.keys.Label(keys.ToggleSteps), "Expand/collapse workflow steps"),
+	)
+
+	return content
+}
+
+// keyRow renders a line of the keyboard reference.
+func keyRow(key, desc string) string {
+	cmdStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
+	descStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	pad := max(9-lipgloss.Width(key), 1)
+	return cmdStyle.Render("  "+key+strings.Repeat(" ", pad)) + descStyle.Render("  "+desc)
+}
+
+// View renders the help content.
+func (m *HelpModal) View() string {
+	content := m.content()
 
 	// Apply scrolling
 	start := m.scroll
@@ -2,13 +2,16 @@ package modal
 
 import (
 	"fmt"
+	"maps"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/pxp/hub-tui/internal/client"
 	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
@@ -28,22 +31,60 @@ const (
 	viewLLMProfileForm
 )
 
+// integrationsClient is the part of the hub-core API used by
+// IntegrationsModal, including its LLM views.
+type integrationsClient interface {
+	ListIntegrations() ([]client.Integration, error)
+	ConfigureIntegration(name, profile string, config map[string]string) error
+	SetIntegrationDefaultProfile(name, profile string) error
+	DeleteIntegrationProfile(name, profile string) error
+	TestIntegration(name string) error
+	ValidateIntegrationConfig(name string, config map[string]string) (*client.ConfigValidation, error)
+	TestIntegrationProfile(name, profile string, config map[string]string) (*client.IntegrationTestResult, error)
+
+	ListLLMProviders(integration string) ([]client.ProviderAccount, error)
+	ListAvailableLLMProviders(integration string) ([]client.AvailableProvider, error)
+	GetLLMProviderFields(integration, provider string) ([]client.ProviderFieldInfo, error)
+	AddLLMProvider(integration string, req client.AddProviderRequest) error
+	DeleteLLMProvider(integration, provider, account string) error
+	ListLLMProfiles(integration string) (*client.LLMProfileList, error)
+	CreateLLMProfile(integration string, req client.CreateProfileRequest) error
+	UpdateLLMProfile(integration, name string, req client.CreateProfileRequest) error
+	DeleteLLMProfile(integration, profile string) error
+	TestLLMProfile(integration, profile string) (*client.LLMTestResult, error)
+	ListLLMModels(integration, provider string, limit int, cursor string) (*client.LLMModelsResult, error)
+	SetDefaultLLMProfile(integration, profile string) error
+	ClearDefaultLLMProfile(integration string) error
+}
+
 // IntegrationsModal displays and configures integrations.
 type IntegrationsModal struct {
-	client       *client.Client
+	client       integrationsClient
 	integrations []client.Integration
 	selected     int
 	loading      bool
 	error        string
+	width        int
+	keys         *keys.Keymap
 
 	// Current view
 	view integrationsView
+	nav  *ViewStack[integrationsView] // Views Esc returns through
+
+	// Integration to open once the list loads (NewIntegrationsModalForTarget)
+	openName string
 
 	// Profile selection (api_key config type)
 	profileSelected int
 	profileOptions  []string // existing profiles + "New profile"
 	newProfileName  string
 	enteringName    bool
+	settingDefault  bool
+
+	// Profile deletion (api_key config type)
+	profileConfirm  *components.Confirmation
+	deletingProfile bool
+	profileWarning  string // Why the selected profile can't be deleted
 
 	// Configure mode (api_key config type)
 	configName    string
@@ -53,6 +94,14 @@ type IntegrationsModal struct {
 	testing       bool
 	testResult    string
 
+	// Profile test from the configure form (api_key config type)
+	profileTesting    bool
+	profileTestResult *client.IntegrationTestResult
+
+	// Config validation from the configure form (api_key config type)
+	validating      bool
+	validatedConfig map[string]string // Last config that passed validation
+
 	// LLM config type state (implemented in integrations_llm.go)
 	llmIntegration client.Integration        // current integration being configured
 	llmProviders   []client.ProviderAccount  // loaded providers
@@ -76,29 +125,66 @@ type IntegrationsModal struct {
 
 	// Model pagination state
 	llmModels            []client.ModelInfo
-	llmModelsCursor      string   // current cursor (empty = first page)
-	llmModelsCursorStack []string // stack of previous cursors for back navigation
-	llmModelsHasMore     bool
-	llmModelsPage        int
+	llmModelsPager       *components.Paginator
+	llmModelsTotal       int // Models across all pages, 0 if the server didn't say
 	llmLoadingModels     bool
+	llmModelsGen         int // incremented per model load to discard stale responses
 
 	// LLM profile testing state
 	llmTesting    bool
 	llmTestResult *client.LLMTestResult
 
+	// LLM bulk testing state (results keyed by profile name)
+	llmTestingAll  bool
+	llmTestResults map[string]*client.LLMTestResult
+
 	// LLM confirmation state
-	llmConfirm components.Confirmation
+	llmConfirm *components.Confirmation
+
+	// LLM list mouse state
+	llmRows   listHitMap // Content line of each LLM list item
+	llmClicks clickTracker
+
+	// LLM profile display order by integration; unlisted profiles follow
+	// alphabetically
+	llmProfileOrders map[string][]string
+
+	// Last LLM delete, restorable with u until the undo window closes
+	llmUndo    *llmUndo
+	llmUndoGen int
+
+	// Profiles | Providers tabs of the LLM config view
+	llmTabs *components.Tabs
+
+	spinner *components.Spinner
 }
 
 // NewIntegrationsModal creates a new integrations modal.
-func NewIntegrationsModal(c *client.Client) *IntegrationsModal {
+// confirmTimeout sets the double-press delete window (zero uses the default).
+func NewIntegrationsModal(c integrationsClient, confirmTimeout time.Duration) *IntegrationsModal {
 	return &IntegrationsModal{
-		client:  c,
-		loading: true,
-		view:    viewList,
+		client:     c,
+		loading:    true,
+		view:       viewList,
+		nav:        NewViewStack(viewList),
+		llmConfirm: components.NewConfirmation().WithTimeout(confirmTimeout),
+		spinner:    components.NewSpinner(),
+		keys:       keys.Default(),
+
+		profileConfirm: components.NewConfirmation().WithTimeout(confirmTimeout),
+		llmTabs:        components.NewTabs("Profiles", "Providers"),
+		llmModelsPager: components.NewPaginator(),
 	}
 }
 
+// NewIntegrationsModalForTarget creates an integrations modal that opens
+// the named integration's profiles or LLM configuration once the list loads.
+func NewIntegrationsModalForTarget(c integrationsClient, confirmTimeout time.Duration, name string) *IntegrationsModal {
+	m := NewIntegrationsModal(c, confirmTimeout)
+	m.openName = name
+	return m
+}
+
 // IntegrationsLoadedMsg is sent when integrations are loaded.
 type IntegrationsLoadedMsg struct {
 	Integrations []client.Integration
@@ -117,6 +203,36 @@ type IntegrationTestedMsg struct {
 	Error error
 }
 
+// IntegrationDefaultSetMsg is sent when an integration's default profile is changed.
+type IntegrationDefaultSetMsg struct {
+	Name    string
+	Profile string
+	Error   error
+}
+
+// IntegrationProfileDeletedMsg is sent when an integration profile is deleted.
+type IntegrationProfileDeletedMsg struct {
+	Name    string
+	Profile string
+	Error   error
+}
+
+// IntegrationConfigValidatedMsg is sent when an integration config is validated.
+type IntegrationConfigValidatedMsg struct {
+	Name   string
+	Config map[string]string
+	Result *client.ConfigValidation
+	Error  error
+}
+
+// IntegrationProfileTestedMsg is sent when an integration profile is tested.
+type IntegrationProfileTestedMsg struct {
+	Name    string
+	Profile string
+	Result  *client.IntegrationTestResult
+	Error   error
+}
+
 // Init initializes the modal and triggers data fetch.
 func (m *IntegrationsModal) Init() tea.Cmd {
 	return m.loadIntegrations()
@@ -129,8 +245,32 @@ func (m *IntegrationsModal) loadIntegrations() tea.Cmd {
 	}
 }
 
-func (m *IntegrationsModal) configureIntegration() tea.Cmd {
+// validateKey is the key of the configure form's Validate button.
+const validateKey = "validate"
+
+// configValues returns the configure form's values without the Validate button.
+func (m *IntegrationsModal) configValues() map[string]string {
 	config := m.form.Values()
+	delete(config, validateKey)
+	return config
+}
+
+// isValidated reports whether the form's current values passed validation.
+func (m *IntegrationsModal) isValidated() bool {
+	return m.validatedConfig != nil && maps.Equal(m.validatedConfig, m.configValues())
+}
+
+func (m *IntegrationsModal) validateConfig() tea.Cmd {
+	config := m.configValues()
+	name := m.configName
+	return func() tea.Msg {
+		result, err := m.client.ValidateIntegrationConfig(name, config)
+		return IntegrationConfigValidatedMsg{Name: name, Config: config, Result: result, Error: err}
+	}
+}
+
+func (m *IntegrationsModal) configureIntegration() tea.Cmd {
+	config := m.configValues()
 	name := m.configName
 	profile := m.configProfile
 	return func() tea.Msg {
@@ -139,6 +279,33 @@ func (m *IntegrationsModal) configureIntegration() tea.Cmd {
 	}
 }
 
+func (m *IntegrationsModal) setIntegrationDefault(profile string) tea.Cmd {
+	name := m.configName
+	return func() tea.Msg {
+		err := m.client.SetIntegrationDefaultProfile(name, profile)
+		return IntegrationDefaultSetMsg{Name: name, Profile: profile, Error: err}
+	}
+}
+
+func (m *IntegrationsModal) deleteIntegrationProfile(profile string) tea.Cmd {
+	name := m.configName
+	return func() tea.Msg {
+		err := m.client.DeleteIntegrationProfile(name, profile)
+		return IntegrationProfileDeletedMsg{Name: name, Profile: profile, Error: err}
+	}
+}
+
+// testProfileConfig tests the profile being configured with the form's values.
+func (m *IntegrationsModal) testProfileConfig() tea.Cmd {
+	config := m.configValues()
+	name := m.configName
+	profile := m.configProfile
+	return func() tea.Msg {
+		result, err := m.client.TestIntegrationProfile(name, profile, config)
+		return IntegrationProfileTestedMsg{Name: name, Profile: profile, Result: result, Error: err}
+	}
+}
+
 func (m *IntegrationsModal) testIntegration() tea.Cmd {
 	name := m.integrations[m.selected].Name
 	return func() tea.Msg {
@@ -147,9 +314,32 @@ func (m *IntegrationsModal) testIntegration() tea.Cmd {
 	}
 }
 
+// IsLoading returns true while any integration or LLM data is being fetched.
+func (m *IntegrationsModal) IsLoading() bool {
+	return m.loading || m.testing || m.saving || m.settingDefault || m.deletingProfile || m.profileTesting || m.validating ||
+		m.llmLoading || m.llmLoadingFields || m.llmLoadingModels ||
+		m.llmSavingProvider || m.llmSavingProfile || m.llmTesting || m.llmTestingAll
+}
+
+// IsCapturingInput returns true while a profile name is being typed or a
+// configuration or LLM form is open.
+func (m *IntegrationsModal) IsCapturingInput() bool {
+	switch m.view {
+	case viewProfiles:
+		return m.enteringName
+	case viewConfigure, viewLLMProviderForm, viewLLMProfileForm:
+		return true
+	}
+	return false
+}
+
 // Update handles input.
 func (m *IntegrationsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	switch msg := msg.(type) {
+	case components.SpinnerTickMsg:
+		m.spinner.Advance()
+		return m, nil
+
 	case IntegrationsLoadedMsg:
 		m.loading = false
 		if msg.Error != nil {
@@ -157,6 +347,17 @@ func (m *IntegrationsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 		} else {
 			m.integrations = msg.Integrations
 			m.error = ""
+			// The reloaded list may be shorter
+			if m.selected >= len(m.integrations) {
+				m.selected = max(len(m.integrations)-1, 0)
+			}
+			if m.view == viewProfiles && !m.buildProfileOptions() {
+				m.view = m.nav.PopTo(viewList)
+				m.error = "Integration " + m.configName + " is no longer available"
+			}
+			if m.openName != "" {
+				return m.openPending()
+			}
 		}
 		return m, nil
 
@@ -166,13 +367,60 @@ func (m *IntegrationsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 			m.error = msg.Error.Error()
 		} else {
 			// Success - go back to list and refresh
-			m.view = viewList
+			m.view = m.nav.PopTo(viewList)
 			m.form = nil
 			m.loading = true
 			return m, m.loadIntegrations()
 		}
 		return m, nil
 
+	case IntegrationDefaultSetMsg:
+		m.settingDefault = false
+		if msg.Error != nil {
+			m.error = msg.Error.Error()
+			return m, nil
+		}
+		// Refresh so the list and profiles show the new default
+		m.loading = true
+		return m, m.loadIntegrations()
+
+	case IntegrationProfileDeletedMsg:
+		m.deletingProfile = false
+		if msg.Error != nil {
+			m.error = msg.Error.Error()
+			return m, nil
+		}
+		// Refresh so the profiles list drops the deleted profile
+		m.loading = true
+		return m, m.loadIntegrations()
+
+	case IntegrationConfigValidatedMsg:
+		m.validating = false
+		if msg.Error != nil {
+			m.error = msg.Error.Error()
+			return m, nil
+		}
+		m.error = ""
+		if m.form != nil {
+			m.form.ClearErrors()
+			for key, errMsg := range msg.Result.Errors {
+				m.form.SetFieldError(key, errMsg)
+			}
+		}
+		if msg.Result.Valid {
+			m.validatedConfig = msg.Config
+		}
+		return m, nil
+
+	case IntegrationProfileTestedMsg:
+		m.profileTesting = false
+		if msg.Error != nil {
+			m.profileTestResult = &client.IntegrationTestResult{Error: msg.Error.Error()}
+		} else {
+			m.profileTestResult = msg.Result
+		}
+		return m, nil
+
 	case IntegrationTestedMsg:
 		m.testing = false
 		if msg.Error != nil {
@@ -218,11 +466,30 @@ func (m *IntegrationsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	case LLMProfileTestedMsg:
 		return m.handleLLMProfileTested(msg)
 
+	case LLMProfilesTestedMsg:
+		return m.handleLLMProfilesTested(msg)
+
 	case LLMProfileDefaultSetMsg:
 		return m.handleLLMProfileDefaultSet(msg)
 
+	case LLMUndoneMsg:
+		return m.handleLLMUndone(msg)
+
+	case LLMUndoExpiredMsg:
+		if msg.Gen == m.llmUndoGen {
+			m.llmUndo = nil
+		}
+		return m, nil
+
 	case components.ConfirmationExpiredMsg:
 		m.llmConfirm.HandleExpired(msg)
+		m.profileConfirm.HandleExpired(msg)
+		return m, nil
+
+	case tea.MouseMsg:
+		if m.view == viewConfigLLM {
+			return m.updateLLMMouse(msg)
+		}
 		return m, nil
 
 	case tea.KeyMsg:
@@ -240,26 +507,49 @@ func (m *IntegrationsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	return m, nil
 }
 
+// openPending opens the integration the modal was created for. Unknown
+// names leave the list showing with an error.
+func (m *IntegrationsModal) openPending() (Modal, tea.Cmd) {
+	name := m.openName
+	m.openName = ""
+	for i, integration := range m.integrations {
+		if integration.Name != name {
+			continue
+		}
+		m.selected = i
+		if isLLMIntegration(integration) {
+			return m.enterLLMConfig(integration)
+		}
+		if integration.ConfigType == "api_key" || integration.ConfigType == "" {
+			m.enterProfilesView()
+		}
+		return m, nil
+	}
+	m.error = fmt.Sprintf("Unknown integration: %s", name)
+	return m, nil
+}
+
 func (m *IntegrationsModal) updateList(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch key := msg.String(); {
+	case key == "esc":
 		return nil, nil // Close modal
-	case "up", "k":
-		if m.selected > 0 {
-			m.selected--
+	case m.keys.Is(msg, keys.ListUp):
+		if len(m.integrations) > 1 {
+			m.selected = moveUp(m.selected, len(m.integrations))
 			m.testResult = ""
 		}
-	case "down", "j":
-		if m.selected < len(m.integrations)-1 {
-			m.selected++
+	case m.keys.Is(msg, keys.ListDown):
+		if len(m.integrations) > 1 {
+			m.selected = moveDown(m.selected, len(m.integrations))
 			m.testResult = ""
 		}
-	case "enter":
+	case key == "enter":
 		if !m.loading && len(m.integrations) > 0 {
 			integration := m.integrations[m.selected]
-			switch integration.ConfigType {
-			case "llm":
+			if isLLMIntegration(integration) {
 				return m.enterLLMConfig(integration)
+			}
+			switch integration.ConfigType {
 			case "api_key", "":
 				// api_key is the default for backwards compatibility
 				m.enterProfilesView()
@@ -267,13 +557,13 @@ func (m *IntegrationsModal) updateList(msg tea.KeyMsg) (Modal, tea.Cmd) {
 				m.error = fmt.Sprintf("Unknown config type: %s", integration.ConfigType)
 			}
 		}
-	case "t":
+	case m.keys.Is(msg, keys.Test):
 		if !m.loading && !m.testing && len(m.integrations) > 0 {
 			m.testing = true
 			m.testResult = ""
 			return m, m.testIntegration()
 		}
-	case "r":
+	case key == "r":
 		m.loading = true
 		m.error = ""
 		m.testResult = ""
@@ -315,20 +605,24 @@ func (m *IntegrationsModal) updateProfiles(msg tea.KeyMsg) (Modal, tea.Cmd) {
 		}
 	}
 
-	switch msg.String() {
-	case "esc":
-		m.view = viewList
+	// Any key other than delete cancels a pending delete
+	if !m.keys.Is(msg, keys.Delete) {
+		m.profileConfirm.Clear()
+		m.profileWarning = ""
+	}
+
+	switch key := msg.String(); {
+	case key == "esc":
+		m.popView()
 		m.error = ""
 		return m, nil
-	case "up", "k":
-		if m.profileSelected > 0 {
-			m.profileSelected--
-		}
-	case "down", "j":
-		if m.profileSelected < len(m.profileOptions)-1 {
-			m.profileSelected++
-		}
-	case "enter":
+	case m.keys.Is(msg, keys.ListUp):
+		m.profileSelected = moveUp(m.profileSelected, len(m.profileOptions))
+	case m.keys.Is(msg, keys.ListDown):
+		m.profileSelected = moveDown(m.profileSelected, len(m.profileOptions))
+	case m.keys.Is(msg, keys.Delete):
+		return m.deleteSelectedProfile()
+	case key == "enter":
 		option := m.profileOptions[m.profileSelected]
 		if option == "+ New profile" {
 			m.enteringName = true
@@ -337,56 +631,163 @@ func (m *IntegrationsModal) updateProfiles(msg tea.KeyMsg) (Modal, tea.Cmd) {
 			m.configProfile = option
 			m.enterConfigureMode()
 		}
+	case m.keys.Is(msg, keys.SetDefault):
+		// Set as default profile
+		option := m.profileOptions[m.profileSelected]
+		if option != "+ New profile" && !m.settingDefault &&
+			option != m.integrations[m.selected].DefaultProfile {
+			m.settingDefault = true
+			m.error = ""
+			return m, m.setIntegrationDefault(option)
+		}
 	}
 	return m, nil
 }
 
 func (m *IntegrationsModal) updateConfigure(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.view = viewProfiles
+	switch key := msg.String(); {
+	case key == "esc":
+		// Discarding edits takes a second press
+		if m.form != nil && m.form.IsDirty() {
+			if execute, cmd := m.profileConfirm.Check("discard", m.configProfile); !execute {
+				return m, cmd
+			}
+		}
+		m.popView()
 		m.form = nil
 		m.error = ""
+		m.profileConfirm.Clear()
 		return m, nil
-	case "ctrl+s":
+	case m.keys.Is(msg, keys.Save):
 		if !m.saving && m.form != nil {
+			// Saving an unvalidated config takes a second press
+			if !m.isValidated() {
+				if execute, cmd := m.profileConfirm.Check("save", m.configProfile); !execute {
+					return m, cmd
+				}
+			}
+			m.profileConfirm.Clear()
 			m.saving = true
 			return m, m.configureIntegration()
 		}
 		return m, nil
+	case m.keys.Is(msg, keys.TestForm):
+		// Test the profile with the values entered so far
+		if !m.profileTesting && m.form != nil {
+			m.profileTesting = true
+			m.profileTestResult = nil
+			return m, m.testProfileConfig()
+		}
+		return m, nil
 	}
 
-	// Forward to form
+	// Forward to form; edits make a previous test result stale
 	if m.form != nil {
-		m.form.Update(msg)
+		m.profileConfirm.Clear()
+		if m.form.Update(msg) {
+			// Enter on the Validate button
+			if !m.validating {
+				m.validating = true
+				m.error = ""
+				return m, m.validateConfig()
+			}
+			return m, nil
+		}
+		m.profileTestResult = nil
+	}
+	return m, nil
+}
+
+// deleteSelectedProfile deletes the selected profile on the second press of d.
+// The default profile and an integration's only profile are kept.
+func (m *IntegrationsModal) deleteSelectedProfile() (Modal, tea.Cmd) {
+	option := m.profileOptions[m.profileSelected]
+	if option == "+ New profile" || m.deletingProfile {
+		return m, nil
+	}
+
+	integration := m.integrations[m.selected]
+	switch {
+	case len(integration.Profiles) <= 1:
+		m.profileWarning = "Cannot delete the only profile."
+		return m, nil
+	case option == integration.DefaultProfile:
+		m.profileWarning = "Cannot delete the default profile. Set another default first."
+		return m, nil
+	}
+
+	if execute, cmd := m.profileConfirm.Check("profile:"+option, option); execute {
+		m.deletingProfile = true
+		m.error = ""
+		return m, m.deleteIntegrationProfile(option)
+	} else if cmd != nil {
+		return m, cmd
 	}
 	return m, nil
 }
 
+// isLLMIntegration reports whether an integration is configured through
+// LLM providers and profiles rather than API key profiles.
+func isLLMIntegration(integration client.Integration) bool {
+	return integration.ConfigType == "llm" || integration.Type == "llm"
+}
+
+// integrationBadge returns the short type label shown next to an
+// integration's name ("" if the type is unknown).
+func integrationBadge(integration client.Integration) string {
+	if isLLMIntegration(integration) {
+		return "llm"
+	}
+	return integration.Type
+}
+
 func (m *IntegrationsModal) enterProfilesView() {
 	integration := m.integrations[m.selected]
 	m.configName = integration.Name
-	m.view = viewProfiles
+	m.pushView(viewProfiles)
 	m.profileSelected = 0
 	m.error = ""
+	m.profileWarning = ""
+	m.profileConfirm.Clear()
+	m.buildProfileOptions()
+}
 
-	// Build profile options: existing profiles + new profile option
-	m.profileOptions = make([]string, 0, len(integration.Profiles)+1)
-	for _, p := range integration.Profiles {
-		m.profileOptions = append(m.profileOptions, p)
+// buildProfileOptions lists the selected integration's profiles plus the
+// new profile option, keeping the selection in range. Returns false if the
+// integration is no longer listed.
+func (m *IntegrationsModal) buildProfileOptions() bool {
+	// The list may have been reloaded; find the integration by name
+	found := false
+	for i, integration := range m.integrations {
+		if integration.Name == m.configName {
+			m.selected = i
+			found = true
+			break
+		}
+	}
+	if !found {
+		m.profileOptions = nil
+		return false
 	}
+	integration := m.integrations[m.selected]
+
+	m.profileOptions = make([]string, 0, len(integration.Profiles)+1)
+	m.profileOptions = append(m.profileOptions, integration.Profiles...)
 	m.profileOptions = append(m.profileOptions, "+ New profile")
 
-	// Default to "default" if no profiles exist
-	if len(integration.Profiles) == 0 {
-		m.profileSelected = 0 // Will be "+ New profile"
+	if m.profileSelected >= len(m.profileOptions) {
+		m.profileSelected = len(m.profileOptions) - 1
 	}
+	return true
 }
 
 func (m *IntegrationsModal) enterConfigureMode() {
 	integration := m.integrations[m.selected]
-	m.view = viewConfigure
+	m.pushView(viewConfigure)
 	m.error = ""
+	m.profileTestResult = nil
+	m.validatedConfig = nil
+	m.profileConfirm.Clear()
 
 	// Build form fields from integration's required fields
 	var fields []components.FormField
@@ -410,9 +811,57 @@ func (m *IntegrationsModal) enterConfigureMode() {
 		})
 	}
 
+	fields = append(fields, components.FormField{
+		Label: "Validate",
+		Key:   validateKey,
+		Type:  components.FieldButton,
+	})
+
 	m.form = components.NewForm("Configure "+integration.Name, fields)
 }
 
+// pushView opens a nested view; Esc returns to the current one.
+func (m *IntegrationsModal) pushView(view integrationsView) {
+	m.nav.Push(view)
+	m.view = view
+}
+
+// popView returns to the view the current one was opened from.
+func (m *IntegrationsModal) popView() {
+	m.view = m.nav.Pop()
+}
+
+// Breadcrumb returns the path to the current view, for the title bar.
+func (m *IntegrationsModal) Breadcrumb() []string {
+	views := m.nav.Views()
+	crumbs := make([]string, len(views))
+	for i, view := range views {
+		crumbs[i] = m.viewLabel(view)
+	}
+	return crumbs
+}
+
+// viewLabel names a view in the breadcrumb.
+func (m *IntegrationsModal) viewLabel(view integrationsView) string {
+	switch view {
+	case viewProfiles:
+		return m.configName
+	case viewConfigure:
+		return m.configProfile
+	case viewConfigLLM:
+		return m.llmIntegration.DisplayName
+	case viewLLMProviderForm:
+		return "Add Provider"
+	case viewLLMProfileForm:
+		if m.llmEditingProfile != nil {
+			return m.llmEditingProfile.Name
+		}
+		return "New Profile"
+	default:
+		return "Integrations"
+	}
+}
+
 // Title returns the modal title.
 func (m *IntegrationsModal) Title() string {
 	switch m.view {
@@ -431,6 +880,16 @@ func (m *IntegrationsModal) Title() string {
 	}
 }
 
+// SetKeymap sets the key bindings.
+func (m *IntegrationsModal) SetKeymap(k *keys.Keymap) {
+	m.keys = k
+}
+
+// SetWidth sets the available content width for wrapping.
+func (m *IntegrationsModal) SetWidth(width int) {
+	m.width = width
+}
+
 // View renders the modal content.
 func (m *IntegrationsModal) View() string {
 	switch m.view {
@@ -447,26 +906,22 @@ func (m *IntegrationsModal) View() string {
 
 func (m *IntegrationsModal) viewListContent() string {
 	if m.loading {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("Loading integrations...")
+		return m.spinner.View("Loading integrations...")
 	}
 
 	if m.error != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 		hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
-			errorStyle.Render("Error: "+m.error),
+			renderError("Error: "+m.error, m.width, 0),
 			"",
 			hintStyle.Render("[r] Retry"),
 		)
 	}
 
 	if len(m.integrations) == 0 {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("No integrations found.")
+		return emptyState("No integrations found.",
+			"Integrations come from modules — enable one in /modules, then [r] Refresh.")
 	}
 
 	var lines []string
@@ -476,6 +931,9 @@ func (m *IntegrationsModal) viewListContent() string {
 	selectedStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
 	normalStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
 	descStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	badgeLLMStyle := lipgloss.NewStyle().Foreground(theme.Accent)
+	badgeAPIStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	badgeOtherStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 
 	for i, integration := range m.integrations {
 		// Status indicator
@@ -501,8 +959,24 @@ func (m *IntegrationsModal) viewListContent() string {
 		// Build line with status info
 		line := fmt.Sprintf("  %s %s", indicator, name)
 
+		// Type badge
+		badge := integrationBadge(integration)
+		if badge != "" {
+			badgeStyle := badgeOtherStyle
+			switch badge {
+			case "llm":
+				badgeStyle = badgeLLMStyle
+			case "api":
+				badgeStyle = badgeAPIStyle
+			}
+			line += " " + badgeStyle.Render(badge)
+		}
+
 		// Pad name for alignment
 		padding := 16 - len(displayName)
+		if badge != "" {
+			padding -= len(badge) + 1
+		}
 		if padding < 2 {
 			padding = 2
 		}
@@ -510,7 +984,14 @@ func (m *IntegrationsModal) viewListContent() string {
 		// Show status - profiles for api_key type, simple status for others
 		var statusStr string
 		if integration.Configured && len(integration.Profiles) > 0 {
-			statusStr = strings.Join(integration.Profiles, ", ")
+			profiles := make([]string, len(integration.Profiles))
+			for j, p := range integration.Profiles {
+				profiles[j] = p
+				if p == integration.DefaultProfile {
+					profiles[j] += " ★"
+				}
+			}
+			statusStr = strings.Join(profiles, ", ")
 		} else if !integration.Configured {
 			statusStr = "Not configured"
 		}
@@ -530,21 +1011,19 @@ func (m *IntegrationsModal) viewListContent() string {
 		} else {
 			resultStyle = lipgloss.NewStyle().Foreground(theme.Error)
 		}
-		lines = append(lines, "  "+resultStyle.Render(m.testResult))
+		lines = append(lines, wrapText(resultStyle, m.testResult, m.width, 2))
 	}
 
 	// Add testing indicator
 	if m.testing {
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Testing..."))
+		lines = append(lines, "  "+m.spinner.View("Testing..."))
 	}
 
 	// Add hints
 	lines = append(lines, "")
 	legendStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	lines = append(lines, legendStyle.Render("  [Enter] Configure  [t] Test  [r] Refresh"))
+	lines = append(lines, legendStyle.Render("  [Enter] Configure  ["+m.keys.Label(keys.Test)+"] Test  [r] Refresh"))
 
 	return strings.Join(lines, "\n")
 }
@@ -555,6 +1034,7 @@ func (m *IntegrationsModal) viewProfilesContent() string {
 	selectedStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
 	normalStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
 	newStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	defaultStyle := lipgloss.NewStyle().Foreground(theme.Warning)
 
 	// Show entering name mode
 	if m.enteringName {
@@ -570,6 +1050,7 @@ func (m *IntegrationsModal) viewProfilesContent() string {
 	}
 
 	// Show profile options
+	defaultProfile := m.integrations[m.selected].DefaultProfile
 	for i, option := range m.profileOptions {
 		var line string
 		if option == "+ New profile" {
@@ -584,14 +1065,39 @@ func (m *IntegrationsModal) viewProfilesContent() string {
 			} else {
 				line = "  " + normalStyle.Render("○ "+option)
 			}
+			if option == defaultProfile {
+				line += " " + defaultStyle.Render("★")
+			}
 		}
 		lines = append(lines, line)
 	}
 
+	if m.settingDefault {
+		lines = append(lines, "")
+		lines = append(lines, "  "+m.spinner.View("Setting default..."))
+	}
+	if m.deletingProfile {
+		lines = append(lines, "")
+		lines = append(lines, "  "+m.spinner.View("Deleting..."))
+	}
+
+	warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	if m.profileConfirm.IsPendingAny() {
+		lines = append(lines, "")
+		lines = append(lines, warnStyle.Render("  Press "+m.keys.Label(keys.Delete)+" again to delete "+m.profileConfirm.PendingID()))
+	} else if m.profileWarning != "" {
+		lines = append(lines, "")
+		lines = append(lines, wrapText(warnStyle, m.profileWarning, m.width, 2))
+	}
+	if m.error != "" {
+		lines = append(lines, "")
+		lines = append(lines, renderError("Error: "+m.error, m.width, 2))
+	}
+
 	// Add hints
 	lines = append(lines, "")
 	legendStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	lines = append(lines, legendStyle.Render("  [Enter] Select  [Esc] Back"))
+	lines = append(lines, legendStyle.Render("  [Enter] Select  ["+m.keys.Label(keys.SetDefault)+"] Set default  ["+m.keys.Label(keys.Delete)+"] Delete  [Esc] Back"))
 
 	return strings.Join(lines, "\n")
 }
@@ -601,28 +1107,61 @@ func (m *IntegrationsModal) viewConfigureContent() string {
 
 	// Show form
 	if m.form != nil {
+		m.form.SetWidth(m.width)
 		lines = append(lines, m.form.View())
 	}
 
 	// Show error if any
 	if m.error != "" {
 		lines = append(lines, "")
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
-		lines = append(lines, "  "+errorStyle.Render("Error: "+m.error))
+		lines = append(lines, renderError("Error: "+m.error, m.width, 2))
 	}
 
 	// Show saving indicator
 	if m.saving {
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Saving..."))
+		lines = append(lines, "  "+m.spinner.View("Saving..."))
+	}
+
+	// Show validation state
+	warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	if m.validating {
+		lines = append(lines, "")
+		lines = append(lines, "  "+m.spinner.View("Validating..."))
+	} else if m.isValidated() {
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.Success).Render("  ✓ Configuration valid"))
+	} else if m.profileConfirm.IsPending("save", "") {
+		lines = append(lines, "")
+		lines = append(lines, wrapText(warnStyle, "Not validated. Press "+m.keys.Label(keys.Save)+" again to save anyway.", m.width, 2))
+	}
+	if m.profileConfirm.IsPending("discard", "") {
+		lines = append(lines, "")
+		lines = append(lines, warnStyle.Render("  Discard changes? Press Esc again"))
+	}
+
+	// Show profile test result
+	if m.profileTesting {
+		lines = append(lines, "")
+		lines = append(lines, "  "+m.spinner.View("Testing..."))
+	} else if m.profileTestResult != nil {
+		lines = append(lines, "")
+		if m.profileTestResult.Success {
+			successStyle := lipgloss.NewStyle().Foreground(theme.Success)
+			lines = append(lines, successStyle.Render(fmt.Sprintf("  ✓ Test passed (%dms)", m.profileTestResult.LatencyMs)))
+		} else {
+			errMsg := m.profileTestResult.Error
+			if errMsg == "" {
+				errMsg = "Unknown error"
+			}
+			lines = append(lines, renderError("✗ Test failed: "+errMsg, m.width, 2))
+		}
 	}
 
 	// Add hints
 	lines = append(lines, "")
 	legendStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	lines = append(lines, legendStyle.Render("  [Ctrl+S] Save  [Esc] Back"))
+	lines = append(lines, legendStyle.Render("  ["+m.keys.Label(keys.Save)+"] Save  ["+m.keys.Label(keys.TestForm)+"] Test  [Esc] Back"))
 
 	return strings.Join(lines, "\n")
 }
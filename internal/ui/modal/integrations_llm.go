@@ -2,13 +2,18 @@ package modal
 
 import (
 	"fmt"
+	"maps"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/pxp/hub-tui/internal/client"
 	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
@@ -58,7 +63,9 @@ type LLMProviderSavedMsg struct {
 
 // LLMProviderDeletedMsg is sent when a provider is deleted.
 type LLMProviderDeletedMsg struct {
-	Err error
+	Provider string
+	Account  string
+	Err      error
 }
 
 // LLMErrorMsg is sent when an LLM operation fails.
@@ -71,6 +78,9 @@ type LLMModelsLoadedMsg struct {
 	Models     []client.ModelInfo
 	HasMore    bool
 	NextCursor string
+	Total      int // Models across all pages
+	Page       int // Which page was loaded
+	Gen        int // Load generation; stale results are dropped
 	Err        error
 }
 
@@ -81,27 +91,60 @@ type LLMProfileSavedMsg struct {
 
 // LLMProfileDeletedMsg is sent when a profile is deleted.
 type LLMProfileDeletedMsg struct {
+	Profile client.LLMProfile
+	Err     error
+}
+
+// LLMUndoneMsg is sent when a deleted profile or provider account is restored.
+type LLMUndoneMsg struct {
 	Err error
 }
 
+// llmUndoWindow is how long a deleted profile or provider account can be restored.
+const llmUndoWindow = 10 * time.Second
+
+// llmUndo holds what is needed to recreate the last deleted LLM item.
+// Exactly one of profile or provider is set.
+type llmUndo struct {
+	profile  *client.LLMProfile
+	provider *client.AddProviderRequest
+}
+
+// LLMUndoExpiredMsg closes the undo window opened by delete generation Gen.
+type LLMUndoExpiredMsg struct {
+	Gen int
+}
+
 // LLMProfileTestedMsg is sent when a profile connectivity test completes.
 type LLMProfileTestedMsg struct {
 	Result *client.LLMTestResult
 	Err    error
 }
 
-// LLMProfileDefaultSetMsg is sent when a profile is set as default.
+// LLMProfilesTestedMsg is sent when a bulk test of all profiles completes.
+// Per-profile failures are recorded in Results; Err is set only on auth failure.
+type LLMProfilesTestedMsg struct {
+	Results map[string]*client.LLMTestResult
+	Err     error
+}
+
+// llmTestWorkers bounds how many profile tests run at once.
+const llmTestWorkers = 4
+
+// LLMProfileDefaultSetMsg is sent when the default profile is set or cleared.
 type LLMProfileDefaultSetMsg struct {
-	Err error
+	Profile string // New default profile ("" when cleared)
+	Err     error
 }
 
 // enterLLMConfig enters the LLM configuration view for the given integration.
 func (m *IntegrationsModal) enterLLMConfig(integration client.Integration) (Modal, tea.Cmd) {
-	m.view = viewConfigLLM
+	m.pushView(viewConfigLLM)
 	m.llmIntegration = integration
 	m.llmLoading = true
 	m.llmError = ""
 	m.llmSelected = 0
+	m.llmTabs.SetSelected(llmTabProfiles)
 	return m, m.loadLLMData()
 }
 
@@ -134,25 +177,137 @@ func (m *IntegrationsModal) handleLLMDataLoaded(msg LLMDataLoadedMsg) (Modal, te
 		return m, nil
 	}
 
+	var selected *llmListItem
+	if m.llmSelected < len(m.llmItems) {
+		item := m.llmItems[m.llmSelected]
+		selected = &item
+	}
+
 	m.llmProviders = msg.Providers
 	m.llmProfiles = msg.Profiles
+	m.sortLLMProfiles()
 	m.llmError = ""
 	m.buildLLMItems()
 
-	// Reset selection if out of bounds
-	if m.llmSelected >= len(m.llmItems) {
+	// Keep the same item selected; clamp if it no longer exists
+	if i := m.findLLMItem(selected); i >= 0 {
+		m.llmSelected = i
+	} else if m.llmSelected >= len(m.llmItems) {
 		m.llmSelected = max(0, len(m.llmItems)-1)
 	}
 
 	return m, nil
 }
 
-// buildLLMItems creates a flattened list for navigation from providers and profiles.
-// Profiles are listed first (more frequently modified), then providers.
+// LLMProfileOrderChangedMsg is sent when an LLM integration's profiles are
+// reordered, so the order can be saved.
+type LLMProfileOrderChangedMsg struct {
+	Integration string
+	Order       []string
+}
+
+// SetProfileOrders sets the LLM profile display order of each integration,
+// keyed by integration name.
+func (m *IntegrationsModal) SetProfileOrders(orders map[string][]string) {
+	m.llmProfileOrders = maps.Clone(orders)
+}
+
+// sortLLMProfiles orders profiles by the integration's custom order, then
+// alphabetically.
+func (m *IntegrationsModal) sortLLMProfiles() {
+	order := m.llmProfileOrders[m.llmIntegration.Name]
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		rank[name] = i
+	}
+	sort.SliceStable(m.llmProfiles, func(i, j int) bool {
+		ri, iListed := rank[m.llmProfiles[i].Name]
+		rj, jListed := rank[m.llmProfiles[j].Name]
+		switch {
+		case iListed && jListed:
+			return ri < rj
+		case iListed != jListed:
+			return iListed
+		default:
+			return m.llmProfiles[i].Name < m.llmProfiles[j].Name
+		}
+	})
+}
+
+// moveLLMProfile moves the selected profile up (delta < 0) or down in the
+// display order and returns a command to save the new order.
+func (m *IntegrationsModal) moveLLMProfile(delta int) tea.Cmd {
+	if m.llmSelected < 0 || m.llmSelected >= len(m.llmItems) ||
+		m.llmItems[m.llmSelected].Type != llmItemProfile {
+		return nil
+	}
+	// Profiles come first in the item list, in display order
+	i := m.llmSelected
+	j := i + delta
+	if j < 0 || j >= len(m.llmProfiles) {
+		return nil
+	}
+	m.llmProfiles[i], m.llmProfiles[j] = m.llmProfiles[j], m.llmProfiles[i]
+
+	// Every shown profile is listed explicitly
+	order := make([]string, len(m.llmProfiles))
+	for k, p := range m.llmProfiles {
+		order[k] = p.Name
+	}
+	integration := m.llmIntegration.Name
+	if m.llmProfileOrders == nil {
+		m.llmProfileOrders = make(map[string][]string)
+	}
+	m.llmProfileOrders[integration] = order
+
+	m.buildLLMItems()
+	m.llmSelected = j
+	return func() tea.Msg {
+		return LLMProfileOrderChangedMsg{Integration: integration, Order: order}
+	}
+}
+
+// findLLMItem returns the index of the item matching target by identity, or -1.
+func (m *IntegrationsModal) findLLMItem(target *llmListItem) int {
+	if target == nil {
+		return -1
+	}
+	for i, item := range m.llmItems {
+		if item.Type != target.Type {
+			continue
+		}
+		switch item.Type {
+		case llmItemProfile:
+			if item.Profile.Name == target.Profile.Name {
+				return i
+			}
+		case llmItemProviderAccount:
+			if item.Provider == target.Provider && item.Account == target.Account {
+				return i
+			}
+		default:
+			return i
+		}
+	}
+	return -1
+}
+
+// Tabs of the LLM config view
+const (
+	llmTabProfiles = iota
+	llmTabProviders
+)
+
+// buildLLMItems creates a flattened list for navigation from the profiles
+// or the provider accounts, depending on the selected tab.
 func (m *IntegrationsModal) buildLLMItems() {
 	m.llmItems = nil
 
-	// Add profiles first (more commonly modified)
+	if m.llmTabs.Selected() == llmTabProviders {
+		m.buildLLMProviderItems()
+		return
+	}
+
 	for i := range m.llmProfiles {
 		m.llmItems = append(m.llmItems, llmListItem{
 			Type:    llmItemProfile,
@@ -164,8 +319,10 @@ func (m *IntegrationsModal) buildLLMItems() {
 	m.llmItems = append(m.llmItems, llmListItem{
 		Type: llmItemNewProfile,
 	})
+}
 
-	// Add provider accounts
+// buildLLMProviderItems lists provider accounts for the Providers tab.
+func (m *IntegrationsModal) buildLLMProviderItems() {
 	for _, p := range m.llmProviders {
 		for _, acct := range p.Accounts {
 			m.llmItems = append(m.llmItems, llmListItem{
@@ -199,35 +356,57 @@ func (m *IntegrationsModal) updateLLM(msg tea.KeyMsg) (Modal, tea.Cmd) {
 	}
 
 	// Clear confirmation and test result on navigation
-	if msg.String() == "j" || msg.String() == "k" || msg.String() == "up" || msg.String() == "down" {
+	if m.keys.Is(msg, keys.ListUp) || m.keys.Is(msg, keys.ListDown) {
 		m.llmConfirm.Clear()
 		m.llmTestResult = nil
 	}
 
-	switch msg.String() {
-	case "esc":
-		m.view = viewList
+	if m.llmTabs.Update(msg) {
+		m.llmConfirm.Clear()
+		m.llmTestResult = nil
+		m.llmSelected = 0
+		m.buildLLMItems()
+		return m, nil
+	}
+
+	switch key := msg.String(); {
+	case key == "esc":
+		m.popView()
 		m.llmError = ""
 		m.llmConfirm.Clear()
+		m.llmUndo = nil
 		return m, nil
 
-	case "j", "down":
-		if m.llmSelected < len(m.llmItems)-1 {
-			m.llmSelected++
+	case key == "u":
+		if m.llmUndo != nil {
+			undo := m.llmUndo
+			m.llmUndo = nil
+			m.llmLoading = true
+			return m, m.undoDelete(undo)
 		}
 
-	case "k", "up":
-		if m.llmSelected > 0 {
-			m.llmSelected--
-		}
+	case m.keys.Is(msg, keys.ListDown):
+		m.llmSelected = moveDown(m.llmSelected, len(m.llmItems))
+
+	case m.keys.Is(msg, keys.ListUp):
+		m.llmSelected = moveUp(m.llmSelected, len(m.llmItems))
+
+	case key == "shift+up":
+		m.llmConfirm.Clear()
+		return m, m.moveLLMProfile(-1)
 
-	case "r":
+	case key == "shift+down":
+		m.llmConfirm.Clear()
+		return m, m.moveLLMProfile(1)
+
+	case key == "r":
 		m.llmLoading = true
 		m.llmError = ""
+		m.llmTestResults = nil
 		m.llmConfirm.Clear()
 		return m, m.loadLLMData()
 
-	case "enter":
+	case key == "enter":
 		if m.llmSelected >= 0 && m.llmSelected < len(m.llmItems) {
 			item := m.llmItems[m.llmSelected]
 			switch item.Type {
@@ -243,27 +422,27 @@ func (m *IntegrationsModal) updateLLM(msg tea.KeyMsg) (Modal, tea.Cmd) {
 			}
 		}
 
-	case "d":
+	case m.keys.Is(msg, keys.Delete):
 		if m.llmSelected >= 0 && m.llmSelected < len(m.llmItems) {
 			item := m.llmItems[m.llmSelected]
 			if item.Type == llmItemProviderAccount {
-				key := "provider:" + item.Provider + "/" + item.Account
-				if execute, cmd := m.llmConfirm.Check(key, item.Account); execute {
+				id := "provider:" + item.Provider + "/" + item.Account
+				if execute, cmd := m.llmConfirm.Check(id, item.Account); execute {
 					return m, m.deleteProvider(item.Provider, item.Account)
 				} else if cmd != nil {
 					return m, cmd
 				}
 			} else if item.Type == llmItemProfile {
-				key := "profile:" + item.Profile.Name
-				if execute, cmd := m.llmConfirm.Check(key, item.Profile.Name); execute {
-					return m, m.deleteProfile(item.Profile.Name)
+				id := "profile:" + item.Profile.Name
+				if execute, cmd := m.llmConfirm.Check(id, item.Profile.Name); execute {
+					return m, m.deleteProfile(*item.Profile)
 				} else if cmd != nil {
 					return m, cmd
 				}
 			}
 		}
 
-	case "t":
+	case m.keys.Is(msg, keys.Test):
 		// Test profile connectivity
 		if m.llmSelected >= 0 && m.llmSelected < len(m.llmItems) {
 			item := m.llmItems[m.llmSelected]
@@ -274,11 +453,23 @@ func (m *IntegrationsModal) updateLLM(msg tea.KeyMsg) (Modal, tea.Cmd) {
 			}
 		}
 
-	case "s":
-		// Set as default profile
+	case key == "T":
+		// Test every profile
+		if len(m.llmProfiles) > 0 && !m.llmTestingAll {
+			m.llmTestingAll = true
+			m.llmTestResult = nil
+			m.llmTestResults = nil
+			return m, m.testAllProfiles()
+		}
+
+	case m.keys.Is(msg, keys.SetDefault):
+		// Set as default profile, or clear it if already default
 		if m.llmSelected >= 0 && m.llmSelected < len(m.llmItems) {
 			item := m.llmItems[m.llmSelected]
-			if item.Type == llmItemProfile && !item.Profile.IsDefault {
+			if item.Type == llmItemProfile {
+				if item.Profile.IsDefault {
+					return m, m.clearDefaultProfile()
+				}
 				return m, m.setDefaultProfile(item.Profile.Name)
 			}
 		}
@@ -287,17 +478,45 @@ func (m *IntegrationsModal) updateLLM(msg tea.KeyMsg) (Modal, tea.Cmd) {
 	return m, nil
 }
 
+// updateLLMMouse selects the clicked LLM list item; a double-click opens it like Enter.
+func (m *IntegrationsModal) updateLLMMouse(msg tea.MouseMsg) (Modal, tea.Cmd) {
+	line, ok := leftClickLine(msg)
+	if !ok || m.llmLoading {
+		return m, nil
+	}
+	i, ok := m.llmRows.itemAt(line)
+	if !ok {
+		return m, nil
+	}
+
+	if i != m.llmSelected {
+		m.llmConfirm.Clear()
+		m.llmTestResult = nil
+	}
+	m.llmSelected = i
+	if m.llmClicks.click(i) {
+		return m.updateLLM(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	return m, nil
+}
+
 // updateLLMProviderForm handles input for the provider form.
 func (m *IntegrationsModal) updateLLMProviderForm(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.view = viewConfigLLM
+	switch key := msg.String(); {
+	case key == "esc":
+		// Discarding edits takes a second press
+		if m.llmProviderForm != nil && m.llmProviderForm.IsDirty() {
+			if execute, cmd := m.llmConfirm.Check("discard", ""); !execute {
+				return m, cmd
+			}
+		}
+		m.popView()
 		m.llmProviderForm = nil
 		m.llmProviderFields = nil
 		m.llmError = ""
 		return m, nil
 
-	case "ctrl+s":
+	case m.keys.Is(msg, keys.Save):
 		if !m.llmSavingProvider && m.llmProviderForm != nil {
 			// Validate before saving
 			if err := m.validateProviderForm(); err != nil {
@@ -317,6 +536,7 @@ func (m *IntegrationsModal) updateLLMProviderForm(msg tea.KeyMsg) (Modal, tea.Cm
 	}
 
 	// Forward to form
+	m.llmConfirm.Clear()
 	if m.llmProviderForm != nil {
 		m.llmProviderForm.Update(msg)
 	}
@@ -362,7 +582,7 @@ func (m *IntegrationsModal) handleLLMAvailableProviders(msg LLMAvailableProvider
 	}
 
 	m.llmAvailableProviders = msg.Providers
-	m.view = viewLLMProviderForm
+	m.pushView(viewLLMProviderForm)
 
 	// Build provider options from available providers
 	providerOptions := make([]string, len(m.llmAvailableProviders))
@@ -469,7 +689,11 @@ func (m *IntegrationsModal) rebuildProviderForm() {
 		fields = append(fields, field)
 	}
 
+	dirty := m.llmProviderForm != nil && m.llmProviderForm.IsDirty()
 	m.llmProviderForm = components.NewForm("Add Provider Account", fields)
+	if dirty {
+		m.llmProviderForm.MarkDirty()
+	}
 }
 
 // validateProviderForm validates the provider form before saving.
@@ -541,7 +765,7 @@ func (m *IntegrationsModal) handleLLMProviderSaved(msg LLMProviderSavedMsg) (Mod
 	}
 
 	// Success - return to config view and refresh
-	m.view = viewConfigLLM
+	m.view = m.nav.PopTo(viewConfigLLM)
 	m.llmProviderForm = nil
 	m.llmLoading = true
 	return m, m.loadLLMData()
@@ -555,7 +779,7 @@ func (m *IntegrationsModal) deleteProvider(provider, account string) tea.Cmd {
 		if err != nil {
 			return LLMProviderDeletedMsg{Err: err}
 		}
-		return LLMProviderDeletedMsg{}
+		return LLMProviderDeletedMsg{Provider: provider, Account: account}
 	}
 }
 
@@ -566,7 +790,52 @@ func (m *IntegrationsModal) handleLLMProviderDeleted(msg LLMProviderDeletedMsg)
 		return m, nil
 	}
 
-	// Success - refresh will remove empty provider headers
+	// Success - refresh will remove empty provider headers. The server
+	// never returns credentials, so undo can only restore the account name.
+	undo := m.offerUndo(&llmUndo{provider: &client.AddProviderRequest{
+		Provider: msg.Provider,
+		Account:  msg.Account,
+	}})
+	return m, tea.Batch(m.loadLLMData(), undo)
+}
+
+// offerUndo makes undo the restorable delete and starts its window.
+func (m *IntegrationsModal) offerUndo(undo *llmUndo) tea.Cmd {
+	m.llmUndo = undo
+	m.llmUndoGen++
+	gen := m.llmUndoGen
+	return tea.Tick(llmUndoWindow, func(time.Time) tea.Msg {
+		return LLMUndoExpiredMsg{Gen: gen}
+	})
+}
+
+// undoDelete recreates the item removed by the last delete.
+func (m *IntegrationsModal) undoDelete(undo *llmUndo) tea.Cmd {
+	integration := m.llmIntegration.Name
+	return func() tea.Msg {
+		if undo.provider != nil {
+			return LLMUndoneMsg{Err: m.client.AddLLMProvider(integration, *undo.provider)}
+		}
+		p := undo.profile
+		err := m.client.CreateLLMProfile(integration, client.CreateProfileRequest{
+			Name:     p.Name,
+			Provider: p.Provider,
+			Account:  p.Account,
+			Model:    p.Model,
+		})
+		if err == nil && p.IsDefault {
+			err = m.client.SetDefaultLLMProfile(integration, p.Name)
+		}
+		return LLMUndoneMsg{Err: err}
+	}
+}
+
+// handleLLMUndone processes the result of restoring a deleted item.
+func (m *IntegrationsModal) handleLLMUndone(msg LLMUndoneMsg) (Modal, tea.Cmd) {
+	if msg.Err != nil {
+		m.llmError = "Undo failed: " + msg.Err.Error()
+	}
+	m.llmLoading = true
 	return m, m.loadLLMData()
 }
 
@@ -576,7 +845,7 @@ const modelsPageSize = 15
 
 // enterLLMProfileForm sets up and enters the profile form.
 func (m *IntegrationsModal) enterLLMProfileForm() (Modal, tea.Cmd) {
-	m.view = viewLLMProfileForm
+	m.pushView(viewLLMProfileForm)
 	m.llmError = ""
 
 	// Build provider options from configured providers (only those with accounts)
@@ -642,10 +911,7 @@ func (m *IntegrationsModal) enterLLMProfileForm() (Modal, tea.Cmd) {
 
 	// Reset model pagination state
 	m.llmModels = nil
-	m.llmModelsCursor = ""
-	m.llmModelsCursorStack = nil
-	m.llmModelsHasMore = false
-	m.llmModelsPage = 1
+	m.llmModelsPager.Reset()
 
 	// Trigger initial cascade to populate account and model options
 	return m, m.cascadeFromProvider()
@@ -691,24 +957,24 @@ func (m *IntegrationsModal) cascadeFromProvider() tea.Cmd {
 
 	// Reset models and trigger model load
 	m.llmModels = nil
-	m.llmModelsCursor = ""
-	m.llmModelsCursorStack = nil
-	m.llmModelsPage = 1
-	return m.loadModels("")
+	m.llmModelsPager.Reset()
+	return m.loadModels(0)
 }
 
 // cascadeFromAccount reloads models when account changes.
 func (m *IntegrationsModal) cascadeFromAccount() tea.Cmd {
 	m.llmModels = nil
-	m.llmModelsCursor = ""
-	m.llmModelsCursorStack = nil
-	m.llmModelsPage = 1
-	return m.loadModels("")
+	m.llmModelsPager.Reset()
+	return m.loadModels(0)
 }
 
-// loadModels fetches models for the current provider with pagination.
-func (m *IntegrationsModal) loadModels(cursor string) tea.Cmd {
+// loadModels fetches a page of models for the current provider.
+// Each call starts a new generation so responses to superseded loads are ignored.
+func (m *IntegrationsModal) loadModels(page int) tea.Cmd {
 	m.llmLoadingModels = true
+	m.llmModelsGen++
+	gen := m.llmModelsGen
+	cursor := m.llmModelsPager.Cursor(page)
 	providerDisplayName := m.llmProfileForm.GetFieldValue("provider")
 	providerName := m.getProviderName(providerDisplayName)
 	integration := m.llmIntegration.Name
@@ -716,18 +982,44 @@ func (m *IntegrationsModal) loadModels(cursor string) tea.Cmd {
 	return func() tea.Msg {
 		result, err := m.client.ListLLMModels(integration, providerName, modelsPageSize, cursor)
 		if err != nil {
-			return LLMModelsLoadedMsg{Err: err}
+			return LLMModelsLoadedMsg{Gen: gen, Err: err}
 		}
 		return LLMModelsLoadedMsg{
 			Models:     result.Models,
 			HasMore:    result.Pagination.HasMore,
 			NextCursor: result.Pagination.NextCursor,
+			Total:      result.Pagination.Total,
+			Page:       page,
+			Gen:        gen,
 		}
 	}
 }
 
+// modelsPageInfo describes the page of models shown, e.g.
+// "Page 2 of 17 (243 models), showing 16–30". Without a total from the
+// server it falls back to "Page 2, showing 16–30".
+func (m *IntegrationsModal) modelsPageInfo() string {
+	page := m.llmModelsPager.Page()
+	first := page*modelsPageSize + 1
+	last := first + len(m.llmModels) - 1
+	if m.llmModelsTotal <= 0 {
+		return fmt.Sprintf("Page %d, showing %d–%d", page+1, first, last)
+	}
+	pages := (m.llmModelsTotal + modelsPageSize - 1) / modelsPageSize
+	noun := "models"
+	if m.llmModelsTotal == 1 {
+		noun = "model"
+	}
+	return fmt.Sprintf("Page %d of %d (%d %s), showing %d–%d", page+1, pages, m.llmModelsTotal, noun, first, last)
+}
+
 // handleLLMModelsLoaded processes the loaded models.
 func (m *IntegrationsModal) handleLLMModelsLoaded(msg LLMModelsLoadedMsg) (Modal, tea.Cmd) {
+	// Drop responses from loads superseded by a provider/account change or closed form
+	if msg.Gen != m.llmModelsGen || m.llmProfileForm == nil {
+		return m, nil
+	}
+
 	m.llmLoadingModels = false
 	if msg.Err != nil {
 		m.llmError = msg.Err.Error()
@@ -735,8 +1027,8 @@ func (m *IntegrationsModal) handleLLMModelsLoaded(msg LLMModelsLoadedMsg) (Modal
 	}
 
 	m.llmModels = msg.Models
-	m.llmModelsHasMore = msg.HasMore
-	m.llmModelsCursor = msg.NextCursor
+	m.llmModelsTotal = msg.Total
+	m.llmModelsPager.Loaded(msg.Page, msg.HasMore, msg.NextCursor)
 
 	// Update model options
 	modelOptions := make([]string, len(m.llmModels))
@@ -756,42 +1048,38 @@ func (m *IntegrationsModal) handleLLMModelsLoaded(msg LLMModelsLoadedMsg) (Modal
 
 // updateLLMProfileForm handles input for the profile form.
 func (m *IntegrationsModal) updateLLMProfileForm(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.view = viewConfigLLM
+	switch key := msg.String(); {
+	case key == "esc":
+		// Discarding edits takes a second press
+		if m.llmProfileForm != nil && m.llmProfileForm.IsDirty() {
+			if execute, cmd := m.llmConfirm.Check("discard", ""); !execute {
+				return m, cmd
+			}
+		}
+		m.popView()
 		m.llmProfileForm = nil
 		m.llmEditingProfile = nil
 		m.llmError = ""
+		m.llmLoadingModels = false
 		return m, nil
 
-	case "ctrl+s":
+	case m.keys.Is(msg, keys.Save):
 		if !m.llmSavingProfile && m.llmProfileForm != nil {
 			m.llmSavingProfile = true
 			return m, m.saveProfile()
 		}
 		return m, nil
 
-	case "p":
+	case key == "p":
 		// Previous page of models (only when model field is focused)
-		if m.llmProfileForm.IsFieldFocused("model") && m.llmModelsPage > 1 {
-			// Pop from cursor stack
-			if len(m.llmModelsCursorStack) > 0 {
-				prevCursor := ""
-				if len(m.llmModelsCursorStack) > 1 {
-					prevCursor = m.llmModelsCursorStack[len(m.llmModelsCursorStack)-2]
-				}
-				m.llmModelsCursorStack = m.llmModelsCursorStack[:len(m.llmModelsCursorStack)-1]
-				m.llmModelsPage--
-				return m, m.loadModels(prevCursor)
-			}
+		if m.llmProfileForm.IsFieldFocused("model") && m.llmModelsPager.HasPrev() {
+			return m, m.loadModels(m.llmModelsPager.Page() - 1)
 		}
 
-	case "n":
+	case key == "n":
 		// Next page of models (only when model field is focused)
-		if m.llmProfileForm.IsFieldFocused("model") && m.llmModelsHasMore {
-			m.llmModelsCursorStack = append(m.llmModelsCursorStack, m.llmModelsCursor)
-			m.llmModelsPage++
-			return m, m.loadModels(m.llmModelsCursor)
+		if m.llmProfileForm.IsFieldFocused("model") && m.llmModelsPager.HasNext() {
+			return m, m.loadModels(m.llmModelsPager.Page() + 1)
 		}
 	}
 
@@ -800,6 +1088,7 @@ func (m *IntegrationsModal) updateLLMProfileForm(msg tea.KeyMsg) (Modal, tea.Cmd
 	prevAccount := m.llmProfileForm.GetFieldValue("account")
 
 	// Let form handle the key
+	m.llmConfirm.Clear()
 	if m.llmProfileForm != nil {
 		m.llmProfileForm.Update(msg)
 	}
@@ -830,27 +1119,34 @@ func (m *IntegrationsModal) saveProfile() tea.Cmd {
 		var err error
 		profileName := values["name"]
 
-		if editingProfile != nil {
-			// For now, delete and recreate (hub-core doesn't have update endpoint)
-			// Delete old profile first if name changed
-			if editingProfile.Name != profileName {
-				_ = m.client.DeleteLLMProfile(integration, editingProfile.Name)
-			} else {
-				_ = m.client.DeleteLLMProfile(integration, profileName)
-			}
-		}
-
-		// Create the profile
-		err = m.client.CreateLLMProfile(integration, client.CreateProfileRequest{
+		req := client.CreateProfileRequest{
 			Name:     profileName,
 			Provider: providerName,
 			Account:  values["account"],
 			Model:    values["model"],
-		})
+		}
+
+		if editingProfile != nil && editingProfile.Name == profileName {
+			err = m.client.UpdateLLMProfile(integration, profileName, req)
+		} else {
+			err = m.client.CreateLLMProfile(integration, req)
+		}
+		if client.IsConflict(err) {
+			return LLMProfileSavedMsg{Err: fmt.Errorf("a profile named %q already exists", profileName)}
+		}
 		if err != nil {
 			return LLMProfileSavedMsg{Err: err}
 		}
 
+		// Renamed: profiles are keyed by name, so remove the old one now that
+		// its replacement exists, and roll back if that fails
+		if editingProfile != nil && editingProfile.Name != profileName {
+			if err := m.client.DeleteLLMProfile(integration, editingProfile.Name); err != nil {
+				_ = m.client.DeleteLLMProfile(integration, profileName)
+				return LLMProfileSavedMsg{Err: fmt.Errorf("failed to rename profile: %w", err)}
+			}
+		}
+
 		// Set default if requested
 		if isDefault {
 			_ = m.client.SetDefaultLLMProfile(integration, profileName)
@@ -869,7 +1165,7 @@ func (m *IntegrationsModal) handleLLMProfileSaved(msg LLMProfileSavedMsg) (Modal
 	}
 
 	// Success - return to config view and refresh
-	m.view = viewConfigLLM
+	m.view = m.nav.PopTo(viewConfigLLM)
 	m.llmProfileForm = nil
 	m.llmEditingProfile = nil
 	m.llmLoading = true
@@ -877,14 +1173,14 @@ func (m *IntegrationsModal) handleLLMProfileSaved(msg LLMProfileSavedMsg) (Modal
 }
 
 // deleteProfile deletes an LLM profile.
-func (m *IntegrationsModal) deleteProfile(profileName string) tea.Cmd {
+func (m *IntegrationsModal) deleteProfile(profile client.LLMProfile) tea.Cmd {
 	integration := m.llmIntegration.Name
 	return func() tea.Msg {
-		err := m.client.DeleteLLMProfile(integration, profileName)
+		err := m.client.DeleteLLMProfile(integration, profile.Name)
 		if err != nil {
 			return LLMProfileDeletedMsg{Err: err}
 		}
-		return LLMProfileDeletedMsg{}
+		return LLMProfileDeletedMsg{Profile: profile}
 	}
 }
 
@@ -896,7 +1192,8 @@ func (m *IntegrationsModal) handleLLMProfileDeleted(msg LLMProfileDeletedMsg) (M
 	}
 
 	// Success - refresh
-	return m, m.loadLLMData()
+	profile := msg.Profile
+	return m, tea.Batch(m.loadLLMData(), m.offerUndo(&llmUndo{profile: &profile}))
 }
 
 // testProfile tests an LLM profile's connectivity.
@@ -911,6 +1208,59 @@ func (m *IntegrationsModal) testProfile(profileName string) tea.Cmd {
 	}
 }
 
+// testAllProfiles tests every profile concurrently with a bounded worker pool.
+func (m *IntegrationsModal) testAllProfiles() tea.Cmd {
+	integration := m.llmIntegration.Name
+	names := make([]string, len(m.llmProfiles))
+	for i, p := range m.llmProfiles {
+		names[i] = p.Name
+	}
+
+	return func() tea.Msg {
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			authErr error
+		)
+		results := make(map[string]*client.LLMTestResult, len(names))
+		jobs := make(chan string)
+
+		for w := 0; w < min(llmTestWorkers, len(names)); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for name := range jobs {
+					result, err := m.client.TestLLMProfile(integration, name)
+					if err != nil {
+						result = &client.LLMTestResult{Success: false, Error: err.Error()}
+					}
+					mu.Lock()
+					results[name] = result
+					if err != nil && client.IsAuthError(err) {
+						authErr = err
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+
+		for _, name := range names {
+			jobs <- name
+		}
+		close(jobs)
+		wg.Wait()
+
+		return LLMProfilesTestedMsg{Results: results, Err: authErr}
+	}
+}
+
+// handleLLMProfilesTested stores the results of a bulk profile test.
+func (m *IntegrationsModal) handleLLMProfilesTested(msg LLMProfilesTestedMsg) (Modal, tea.Cmd) {
+	m.llmTestingAll = false
+	m.llmTestResults = msg.Results
+	return m, nil
+}
+
 // handleLLMProfileTested processes the result of testing a profile.
 func (m *IntegrationsModal) handleLLMProfileTested(msg LLMProfileTestedMsg) (Modal, tea.Cmd) {
 	m.llmTesting = false
@@ -932,6 +1282,18 @@ func (m *IntegrationsModal) setDefaultProfile(profileName string) tea.Cmd {
 		if err != nil {
 			return LLMProfileDefaultSetMsg{Err: err}
 		}
+		return LLMProfileDefaultSetMsg{Profile: profileName}
+	}
+}
+
+// clearDefaultProfile unsets the default profile.
+func (m *IntegrationsModal) clearDefaultProfile() tea.Cmd {
+	integration := m.llmIntegration.Name
+	return func() tea.Msg {
+		err := m.client.ClearDefaultLLMProfile(integration)
+		if err != nil {
+			return LLMProfileDefaultSetMsg{Err: err}
+		}
 		return LLMProfileDefaultSetMsg{}
 	}
 }
@@ -953,6 +1315,7 @@ func (m *IntegrationsModal) viewLLMProfileForm() string {
 
 	// Show form
 	if m.llmProfileForm != nil {
+		m.llmProfileForm.SetWidth(m.width)
 		lines = append(lines, m.llmProfileForm.View())
 	}
 
@@ -974,14 +1337,14 @@ func (m *IntegrationsModal) viewLLMProfileForm() string {
 		}
 
 		// Pagination info
-		if m.llmModelsHasMore || m.llmModelsPage > 1 {
+		if pager := m.llmModelsPager; len(m.llmModels) > 0 && (pager.HasNext() || pager.HasPrev() || m.llmModelsTotal > 0) {
 			lines = append(lines, "")
 			pageStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-			pageInfo := fmt.Sprintf("  Page %d", m.llmModelsPage)
-			if m.llmModelsPage > 1 {
+			pageInfo := "  " + m.modelsPageInfo()
+			if pager.HasPrev() {
 				pageInfo += "  [p] prev"
 			}
-			if m.llmModelsHasMore {
+			if pager.HasNext() {
 				pageInfo += "  [n] next"
 			}
 			lines = append(lines, pageStyle.Render(pageInfo))
@@ -991,30 +1354,30 @@ func (m *IntegrationsModal) viewLLMProfileForm() string {
 	// Show loading indicator for models
 	if m.llmLoadingModels {
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Loading models..."))
+		lines = append(lines, "  "+m.spinner.View("Loading models..."))
 	}
 
 	// Show error if any
 	if m.llmError != "" {
 		lines = append(lines, "")
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
-		lines = append(lines, "  "+errorStyle.Render("Error: "+m.llmError))
+		lines = append(lines, renderError("Error: "+m.llmError, m.width, 2))
 	}
 
 	// Show saving indicator
 	if m.llmSavingProfile {
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Saving..."))
+		lines = append(lines, "  "+m.spinner.View("Saving..."))
 	}
 
 	// Hints
 	lines = append(lines, "")
 	hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	lines = append(lines, hintStyle.Render("  [Ctrl+S] Save  [Esc] Cancel"))
+	if m.llmConfirm.IsPending("discard", "") {
+		warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+		lines = append(lines, warnStyle.Render("  Discard changes? Press Esc again"))
+	} else {
+		lines = append(lines, hintStyle.Render("  ["+m.keys.Label(keys.Save)+"] Save  [Esc] Cancel"))
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -1029,18 +1392,16 @@ func (m *IntegrationsModal) viewLLM() string {
 		return m.viewLLMProfileForm()
 	}
 
+	m.llmRows.reset()
 	if m.llmLoading {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Loading...")
+		return "  " + m.spinner.View("Loading...")
 	}
 
 	if m.llmError != "" && len(m.llmItems) == 0 {
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 		hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
-			errorStyle.Render("  Error: "+m.llmError),
+			renderError("Error: "+m.llmError, m.width, 2),
 			"",
 			hintStyle.Render("  [r] Retry  [Esc] Back"),
 		)
@@ -1049,7 +1410,6 @@ func (m *IntegrationsModal) viewLLM() string {
 	var lines []string
 
 	// Styles
-	headerStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary).Bold(true)
 	providerStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 	selectedStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
 	normalStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
@@ -1057,8 +1417,7 @@ func (m *IntegrationsModal) viewLLM() string {
 	dimStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 	newItemStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 
-	// --- Profiles Section (first - more frequently modified) ---
-	lines = append(lines, headerStyle.Render("  Profiles"))
+	lines = append(lines, "  "+m.llmTabs.View(), "")
 
 	for i, item := range m.llmItems {
 		if item.Type == llmItemProfile {
@@ -1091,10 +1450,21 @@ func (m *IntegrationsModal) viewLLM() string {
 				profileLine = cursor + normalStyle.Render(defaultMark+namePadded) + dimStyle.Render(info)
 			}
 
+			// Bulk test result
+			if result, ok := m.llmTestResults[profile.Name]; ok {
+				if result.Success {
+					profileLine += "  " + lipgloss.NewStyle().Foreground(theme.Success).Render(fmt.Sprintf("✓ %dms", result.LatencyMs))
+				} else {
+					profileLine += "  " + lipgloss.NewStyle().Foreground(theme.Error).Render("✗ failed")
+				}
+			}
+
+			m.llmRows.set(len(lines), i)
 			lines = append(lines, profileLine)
 		} else if item.Type == llmItemNewProfile {
 			// Add spacing before "+ New Profile" to separate from list
 			lines = append(lines, "")
+			m.llmRows.set(len(lines), i)
 			cursor := "  "
 			if i == m.llmSelected {
 				cursor = "> "
@@ -1105,14 +1475,6 @@ func (m *IntegrationsModal) viewLLM() string {
 		}
 	}
 
-	// Separator
-	lines = append(lines, "")
-	lines = append(lines, dimStyle.Render("  ─────────────────────────────────"))
-	lines = append(lines, "")
-
-	// --- Providers Section ---
-	lines = append(lines, headerStyle.Render("  Providers"))
-
 	currentProvider := ""
 	for i, item := range m.llmItems {
 		if item.Type == llmItemProviderAccount {
@@ -1133,6 +1495,7 @@ func (m *IntegrationsModal) viewLLM() string {
 			}
 
 			accountLine := cursor + "  • " + item.Account
+			m.llmRows.set(len(lines), i)
 			if i == m.llmSelected {
 				lines = append(lines, selectedStyle.Render(accountLine))
 			} else {
@@ -1141,6 +1504,7 @@ func (m *IntegrationsModal) viewLLM() string {
 		} else if item.Type == llmItemNewProvider {
 			// Add spacing before "+ New Provider" to separate from list
 			lines = append(lines, "")
+			m.llmRows.set(len(lines), i)
 			cursor := "  "
 			if i == m.llmSelected {
 				cursor = "> "
@@ -1154,36 +1518,59 @@ func (m *IntegrationsModal) viewLLM() string {
 	// Error message if present (inline)
 	if m.llmError != "" {
 		lines = append(lines, "")
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
-		lines = append(lines, errorStyle.Render("  Error: "+m.llmError))
+		lines = append(lines, renderError("Error: "+m.llmError, m.width, 2))
 	}
 
 	// Test result
 	if m.llmTesting {
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Testing..."))
+		lines = append(lines, "  "+m.spinner.View("Testing..."))
 	} else if m.llmTestResult != nil {
 		lines = append(lines, "")
 		if m.llmTestResult.Success {
 			successStyle := lipgloss.NewStyle().Foreground(theme.Success)
 			lines = append(lines, successStyle.Render(fmt.Sprintf("  ✓ Test passed (%dms)", m.llmTestResult.LatencyMs)))
 		} else {
-			errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 			errMsg := m.llmTestResult.Error
 			if errMsg == "" {
 				errMsg = "Unknown error"
 			}
-			lines = append(lines, errorStyle.Render("  ✗ Test failed: "+errMsg))
+			lines = append(lines, renderError("✗ Test failed: "+errMsg, m.width, 2))
+		}
+	}
+
+	// Bulk test summary
+	if m.llmTestingAll {
+		lines = append(lines, "")
+		lines = append(lines, "  "+m.spinner.View("Testing all profiles..."))
+	} else if len(m.llmTestResults) > 0 {
+		passed := 0
+		for _, result := range m.llmTestResults {
+			if result.Success {
+				passed++
+			}
+		}
+		summaryStyle := lipgloss.NewStyle().Foreground(theme.Success)
+		if passed < len(m.llmTestResults) {
+			summaryStyle = lipgloss.NewStyle().Foreground(theme.Warning)
 		}
+		lines = append(lines, "")
+		lines = append(lines, summaryStyle.Render(fmt.Sprintf("  %d/%d passed", passed, len(m.llmTestResults))))
 	}
 
 	// Confirmation hint if pending
 	if m.llmConfirm.IsPendingAny() {
 		lines = append(lines, "")
 		warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
-		lines = append(lines, warnStyle.Render("  Press d again to delete "+m.llmConfirm.PendingID()))
+		lines = append(lines, warnStyle.Render("  Press "+m.keys.Label(keys.Delete)+" again to delete "+m.llmConfirm.PendingID()))
+	} else if m.llmUndo != nil {
+		lines = append(lines, "")
+		undoStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+		if m.llmUndo.profile != nil {
+			lines = append(lines, undoStyle.Render("  Deleted profile "+m.llmUndo.profile.Name+" — press u to undo"))
+		} else {
+			lines = append(lines, undoStyle.Render("  Deleted account "+m.llmUndo.provider.Account+" — press u to undo (credentials must be re-entered)"))
+		}
 	}
 
 	// Hints
@@ -1196,20 +1583,21 @@ func (m *IntegrationsModal) viewLLM() string {
 		item := m.llmItems[m.llmSelected]
 		switch item.Type {
 		case llmItemProfile:
+			setDefault := "Set Default"
 			if item.Profile.IsDefault {
-				hints = "  [Enter] Edit  [t] Test  [d] Delete  [r] Refresh  [Esc] Back"
-			} else {
-				hints = "  [Enter] Edit  [t] Test  [s] Set Default  [d] Delete  [r] Refresh  [Esc] Back"
+				setDefault = "Clear Default"
 			}
+			hints = "  [Enter] Edit  [" + m.keys.Label(keys.Test) + "] Test  [T] Test All  [" + m.keys.Label(keys.SetDefault) + "] " + setDefault +
+				"  [" + m.keys.Label(keys.Delete) + "] Delete  [Shift+↑/↓] Reorder  [←/→] Tabs  [r] Refresh  [Esc] Back"
 		case llmItemProviderAccount:
-			hints = "  [d] Delete  [r] Refresh  [Esc] Back"
+			hints = "  [" + m.keys.Label(keys.Delete) + "] Delete  [←/→] Tabs  [r] Refresh  [Esc] Back"
 		case llmItemNewProfile, llmItemNewProvider:
-			hints = "  [Enter] Create  [r] Refresh  [Esc] Back"
+			hints = "  [Enter] Create  [←/→] Tabs  [r] Refresh  [Esc] Back"
 		default:
-			hints = "  [r] Refresh  [Esc] Back"
+			hints = "  [←/→] Tabs  [r] Refresh  [Esc] Back"
 		}
 	} else {
-		hints = "  [r] Refresh  [Esc] Back"
+		hints = "  [←/→] Tabs  [r] Refresh  [Esc] Back"
 	}
 	lines = append(lines, hintStyle.Render(hints))
 
@@ -1222,36 +1610,37 @@ func (m *IntegrationsModal) viewLLMProviderForm() string {
 
 	// Show form
 	if m.llmProviderForm != nil {
+		m.llmProviderForm.SetWidth(m.width)
 		lines = append(lines, m.llmProviderForm.View())
 	}
 
 	// Show loading indicator for fields
 	if m.llmLoadingFields {
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Loading fields..."))
+		lines = append(lines, "  "+m.spinner.View("Loading fields..."))
 	}
 
 	// Show error if any
 	if m.llmError != "" {
 		lines = append(lines, "")
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
-		lines = append(lines, "  "+errorStyle.Render("Error: "+m.llmError))
+		lines = append(lines, renderError("Error: "+m.llmError, m.width, 2))
 	}
 
 	// Show saving indicator
 	if m.llmSavingProvider {
 		lines = append(lines, "")
-		lines = append(lines, lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("  Saving..."))
+		lines = append(lines, "  "+m.spinner.View("Saving..."))
 	}
 
 	// Hints
 	lines = append(lines, "")
 	hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	lines = append(lines, hintStyle.Render("  [Ctrl+S] Save  [Esc] Cancel"))
+	if m.llmConfirm.IsPending("discard", "") {
+		warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+		lines = append(lines, warnStyle.Render("  Discard changes? Press Esc again"))
+	} else {
+		lines = append(lines, hintStyle.Render("  ["+m.keys.Label(keys.Save)+"] Save  [Esc] Cancel"))
+	}
 
 	return strings.Join(lines, "\n")
 }
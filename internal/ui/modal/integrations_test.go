@@ -0,0 +1,216 @@
+package modal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pxp/hub-tui/internal/client"
+)
+
+// recordingClient serves the demo fixtures and records default-profile changes.
+type recordingClient struct {
+	*client.Mock
+	setDefault []string // "integration/profile" per SetDefaultLLMProfile call
+}
+
+func (c *recordingClient) SetDefaultLLMProfile(integration, profile string) error {
+	c.setDefault = append(c.setDefault, integration+"/"+profile)
+	return c.Mock.SetDefaultLLMProfile(integration, profile)
+}
+
+// runCmd feeds the message produced by cmd back into the modal.
+func runCmd(t *testing.T, m Modal, cmd tea.Cmd) Modal {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	m, _ = m.Update(cmd())
+	return m
+}
+
+func keyMsg(k string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func TestSetDefaultLLMProfileKey(t *testing.T) {
+	fake := &recordingClient{Mock: client.NewMock()}
+	im := NewIntegrationsModal(fake, time.Second)
+	var m Modal = runCmd(t, im, im.Init())
+
+	// Open the LLM integration
+	for i, integ := range im.integrations {
+		if integ.Name == "llm" {
+			im.selected = i
+		}
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, m, cmd)
+	if im.view != viewConfigLLM {
+		t.Fatalf("view = %v, want the LLM view", im.view)
+	}
+
+	// Select the non-default "smart" profile and press s
+	im.llmSelected = -1
+	for i, item := range im.llmItems {
+		if item.Type == llmItemProfile && item.Profile.Name == "smart" {
+			im.llmSelected = i
+		}
+	}
+	if im.llmSelected < 0 {
+		t.Fatal("profile \"smart\" not listed")
+	}
+	_, cmd = m.Update(keyMsg("s"))
+	if cmd == nil {
+		t.Fatal("s produced no command")
+	}
+	cmd()
+
+	if len(fake.setDefault) != 1 || fake.setDefault[0] != "llm/smart" {
+		t.Errorf("SetDefaultLLMProfile calls = %v, want [llm/smart]", fake.setDefault)
+	}
+}
+
+func TestProfilesViewSurvivesShorterReload(t *testing.T) {
+	tests := []struct {
+		name   string
+		reload []client.Integration
+		want   integrationsView
+	}{
+		{"empty", nil, viewList},
+		{"integration removed", []client.Integration{{Name: "other", Type: "api"}}, viewList},
+		{"integration kept", []client.Integration{{Name: "github", Type: "api", Profiles: []string{"default"}}}, viewProfiles},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			im := NewIntegrationsModal(client.NewMock(), time.Second)
+			var m Modal = runCmd(t, im, im.Init())
+
+			// Open GitHub and select its last profile option
+			for i, integ := range im.integrations {
+				if integ.Name == "github" {
+					im.selected = i
+				}
+			}
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if im.view != viewProfiles {
+				t.Fatalf("view = %v, want the profiles view", im.view)
+			}
+			im.profileSelected = len(im.profileOptions) - 1
+			im.selected = len(im.integrations) - 1 // Past the end of a shorter reload
+
+			m, _ = m.Update(IntegrationsLoadedMsg{Integrations: tt.reload})
+			if im.view != tt.want {
+				t.Errorf("view = %v, want %v", im.view, tt.want)
+			}
+			m.View() // Must not panic
+			if tt.want == viewList && im.error == "" {
+				t.Error("no error shown for the missing integration")
+			}
+		})
+	}
+}
+
+func TestOpenUnknownIntegration(t *testing.T) {
+	im := NewIntegrationsModalForTarget(client.NewMock(), time.Second, "nope")
+	m := runCmd(t, im, im.Init())
+	if im.view != viewList {
+		t.Fatalf("view = %v, want the list", im.view)
+	}
+	if im.error != "Unknown integration: nope" {
+		t.Errorf("error = %q, want %q", im.error, "Unknown integration: nope")
+	}
+	if im.testResult != "" {
+		t.Errorf("test result = %q, want it left empty", im.testResult)
+	}
+	if view := m.View(); !strings.Contains(view, "Unknown integration: nope") {
+		t.Errorf("list view doesn't show the error:\n%s", view)
+	}
+}
+
+func TestLLMProfileOrderPerIntegration(t *testing.T) {
+	profiles := func(names ...string) []client.LLMProfile {
+		ps := make([]client.LLMProfile, len(names))
+		for i, name := range names {
+			ps[i] = client.LLMProfile{Name: name}
+		}
+		return ps
+	}
+	names := func(ps []client.LLMProfile) []string {
+		out := make([]string, len(ps))
+		for i, p := range ps {
+			out[i] = p.Name
+		}
+		return out
+	}
+
+	m := NewIntegrationsModal(client.NewMock(), time.Second)
+	m.SetProfileOrders(map[string][]string{
+		"work":     {"default", "fast"},
+		"personal": {"fast", "default"},
+	})
+
+	m.llmIntegration = client.Integration{Name: "work"}
+	m.llmProfiles = profiles("fast", "default", "other")
+	m.sortLLMProfiles()
+	if got := strings.Join(names(m.llmProfiles), ","); got != "default,fast,other" {
+		t.Errorf("work order = %s, want default,fast,other", got)
+	}
+
+	// Moving a profile in one integration leaves the other's order alone
+	m.buildLLMItems()
+	m.llmSelected = 0
+	cmd := m.moveLLMProfile(1)
+	msg, ok := cmd().(LLMProfileOrderChangedMsg)
+	if !ok || msg.Integration != "work" || strings.Join(msg.Order, ",") != "fast,default,other" {
+		t.Errorf("order change = %+v, want work: fast,default,other", msg)
+	}
+
+	m.llmIntegration = client.Integration{Name: "personal"}
+	m.llmProfiles = profiles("default", "fast")
+	m.sortLLMProfiles()
+	if got := strings.Join(names(m.llmProfiles), ","); got != "fast,default" {
+		t.Errorf("personal order = %s, want fast,default", got)
+	}
+}
+
+func TestIntegrationFormsTakeQ(t *testing.T) {
+	var s State
+	im := NewIntegrationsModal(client.NewMock(), time.Second)
+	s.Open(runCmd(t, im, im.Init()))
+
+	// Open github's profiles and start a new one
+	for i, integ := range im.integrations {
+		if integ.Name == "github" {
+			im.selected = i
+		}
+	}
+	s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	im.profileSelected = len(im.profileOptions) - 1
+	s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !im.enteringName {
+		t.Fatal("not entering a profile name")
+	}
+
+	s.Update(keyMsg("q"))
+	if s.Active == nil {
+		t.Fatal("q closed the modal while typing a profile name")
+	}
+	if im.newProfileName != "q" {
+		t.Errorf("profile name = %q, want q", im.newProfileName)
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if im.view != viewConfigure {
+		t.Fatalf("view = %v, want the configure form", im.view)
+	}
+	s.Update(keyMsg("q"))
+	if s.Active == nil {
+		t.Fatal("q closed the modal while typing into the form")
+	}
+	if got := im.form.GetFieldValue("token"); got != "q" {
+		t.Errorf("token = %q, want q", got)
+	}
+}
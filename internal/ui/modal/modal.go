@@ -1,9 +1,13 @@
 package modal
 
 import (
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
@@ -22,20 +26,114 @@ type FormModal interface {
 	IsFormModal() bool
 }
 
+// InputCapturingModal is an optional interface for modals that sometimes
+// take typed text, like a filter or an inline form. While IsCapturingInput
+// returns true, q is typed rather than closing the modal.
+type InputCapturingModal interface {
+	Modal
+	IsCapturingInput() bool
+}
+
+// WidthAwareModal is an optional interface for modals that lay out content
+// to the available width. State calls SetWidth on open and on resize.
+type WidthAwareModal interface {
+	Modal
+	SetWidth(width int)
+}
+
+// SizedModal is an optional interface for modals that paginate or scroll
+// to the available height. State calls SetHeight on open and on resize.
+type SizedModal interface {
+	Modal
+	SetHeight(height int)
+}
+
+// LoadingModal is an optional interface for modals that show a spinner while loading.
+// State keeps SpinnerTickMsg flowing for as long as IsLoading returns true.
+type LoadingModal interface {
+	Modal
+	IsLoading() bool
+}
+
+// KeymapModal is an optional interface for modals with rebindable keys.
+// State calls SetKeymap on open.
+type KeymapModal interface {
+	Modal
+	SetKeymap(k *keys.Keymap)
+}
+
+// minScrollHeight is the fewest lines a scrolling modal will shrink to.
+const minScrollHeight = 5
+
 // State tracks the currently active modal.
 type State struct {
-	Active Modal
-	width  int
+	Active        Modal
+	width         int
+	height        int
+	spinnerActive bool // Whether a SpinnerTickMsg is in flight
+	keys          *keys.Keymap
 }
 
 // NewState creates a new modal state.
 func NewState() State {
-	return State{}
+	return State{keys: keys.Default()}
+}
+
+// SetKeymap sets the key bindings passed to modals.
+func (s *State) SetKeymap(k *keys.Keymap) {
+	s.keys = k
+	s.applyKeymap()
+}
+
+// keymap returns the key bindings, or the defaults if none were set.
+func (s *State) keymap() *keys.Keymap {
+	if s.keys == nil {
+		return keys.Default()
+	}
+	return s.keys
+}
+
+// applyKeymap passes the key bindings to the active modal if it wants them.
+func (s *State) applyKeymap() {
+	if km, ok := s.Active.(KeymapModal); ok {
+		km.SetKeymap(s.keymap())
+	}
 }
 
 // SetWidth updates the available width for modals.
 func (s *State) SetWidth(width int) {
 	s.width = width
+	s.applyWidth()
+}
+
+// SetHeight updates the total height available to the modal, including its border.
+func (s *State) SetHeight(height int) {
+	s.height = height
+	s.applyHeight()
+}
+
+// contentHeight returns the height inside the border and below the title bar.
+func (s *State) contentHeight() int {
+	return s.height - 4
+}
+
+// applyHeight passes the content height to the active modal if it wants it.
+func (s *State) applyHeight() {
+	if sm, ok := s.Active.(SizedModal); ok && s.height > 0 {
+		sm.SetHeight(s.contentHeight())
+	}
+}
+
+// contentWidth returns the width inside the modal border and padding.
+func (s *State) contentWidth() int {
+	return max(s.width-4, 0)
+}
+
+// applyWidth passes the content width to the active modal if it wants it.
+func (s *State) applyWidth() {
+	if wm, ok := s.Active.(WidthAwareModal); ok {
+		wm.SetWidth(s.contentWidth())
+	}
 }
 
 // IsOpen returns true if a modal is currently open.
@@ -46,7 +144,10 @@ func (s *State) IsOpen() bool {
 // Open opens a modal.
 func (s *State) Open(m Modal) tea.Cmd {
 	s.Active = m
-	return m.Init()
+	s.applyKeymap()
+	s.applyWidth()
+	s.applyHeight()
+	return tea.Batch(m.Init(), s.spinnerCmd())
 }
 
 // Close closes the current modal.
@@ -64,9 +165,13 @@ func (s *State) Update(msg tea.Msg) (bool, tea.Cmd) {
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		// Check if this is a form modal (uses Esc/Ctrl+S, not q)
 		_, isFormModal := s.Active.(FormModal)
+		capturing := false
+		if im, ok := s.Active.(InputCapturingModal); ok {
+			capturing = im.IsCapturingInput()
+		}
 
-		// q closes non-form modals from anywhere
-		if !isFormModal && keyMsg.String() == "q" {
+		// q closes non-form modals unless text is being typed
+		if !isFormModal && !capturing && keyMsg.String() == "q" {
 			s.Active = nil
 			return true, nil
 		}
@@ -80,17 +185,46 @@ func (s *State) Update(msg tea.Msg) (bool, tea.Cmd) {
 	if s.Active == nil {
 		return true, cmd
 	}
-	return true, cmd
+	return true, tea.Batch(cmd, s.spinnerCmd())
 }
 
 // UpdateMsg forwards non-key messages to the modal (e.g., async results).
+// SpinnerTickMsg is always consumed here so the tick loop can stop once the modal closes.
 func (s *State) UpdateMsg(msg tea.Msg) (bool, tea.Cmd) {
+	if _, ok := msg.(components.SpinnerTickMsg); ok {
+		s.spinnerActive = false
+	}
 	if s.Active == nil {
 		return false, nil
 	}
 	var cmd tea.Cmd
 	s.Active, cmd = s.Active.Update(msg)
-	return true, cmd
+	if s.Active == nil {
+		return true, cmd
+	}
+	return true, tea.Batch(cmd, s.spinnerCmd())
+}
+
+// UpdateMouse forwards a mouse event to the modal, translated so that
+// Y is relative to the modal content. top is the screen row of the
+// modal's top border.
+func (s *State) UpdateMouse(msg tea.MouseMsg, top int) (bool, tea.Cmd) {
+	msg.Y -= top + modalHeaderLines
+	msg.X -= 2 // Border and padding
+	return s.UpdateMsg(msg)
+}
+
+// spinnerCmd starts the spinner tick loop if the active modal is loading
+// and no tick is already in flight.
+func (s *State) spinnerCmd() tea.Cmd {
+	if s.spinnerActive {
+		return nil
+	}
+	if lm, ok := s.Active.(LoadingModal); ok && lm.IsLoading() {
+		s.spinnerActive = true
+		return components.SpinnerTick()
+	}
+	return nil
 }
 
 // View renders the modal inline (not as overlay).
@@ -108,22 +242,28 @@ func (s *State) View() string {
 	hintStyle := lipgloss.NewStyle().
 		Foreground(theme.TextSecondary)
 
-	// Build title bar: title on left, hint on right
-	title := titleStyle.Render(s.Active.Title())
-
 	// Different hint for form modals
 	var hint string
 	if _, isFormModal := s.Active.(FormModal); isFormModal {
-		hint = hintStyle.Render("Esc cancel · Ctrl+S save")
+		hint = hintStyle.Render("Esc cancel · " + s.keymap().Label(keys.Save) + " save")
 	} else {
 		hint = hintStyle.Render("q to close")
 	}
 
 	// Calculate padding between title and hint
 	// Border takes 2 chars (left + right), padding takes 2 chars (1 each side)
-	innerWidth := s.width - 4
-	titleWidth := lipgloss.Width(title)
+	innerWidth := s.contentWidth()
 	hintWidth := lipgloss.Width(hint)
+
+	// Build title bar: title on left, hint on right. Nested views show
+	// the path to them instead of the title.
+	title := titleStyle.Render(s.Active.Title())
+	if nm, ok := s.Active.(NavigableModal); ok {
+		if crumbs := nm.Breadcrumb(); len(crumbs) > 1 {
+			title = renderBreadcrumb(crumbs, innerWidth-hintWidth-1, titleStyle, hintStyle)
+		}
+	}
+	titleWidth := lipgloss.Width(title)
 	padding := innerWidth - titleWidth - hintWidth
 	if padding < 1 {
 		padding = 1
@@ -136,7 +276,7 @@ func (s *State) View() string {
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(theme.Accent).
 		Padding(0, 1).
-		Width(s.width - 2) // Account for border
+		Width(max(s.width-2, 0)) // Account for border
 
 	// Build modal content
 	content := lipgloss.JoinVertical(
@@ -149,6 +289,52 @@ func (s *State) View() string {
 	return boxStyle.Render(content)
 }
 
+// breadcrumbSeparator separates the levels of a breadcrumb.
+const breadcrumbSeparator = " › "
+
+// renderBreadcrumb renders the path to a nested view, the current level
+// in titleStyle and its parents in parentStyle. Parents are dropped from
+// the front, replaced by "…", until the path fits width.
+func renderBreadcrumb(crumbs []string, width int, titleStyle, parentStyle lipgloss.Style) string {
+	parents := crumbs[:len(crumbs)-1]
+	current := crumbs[len(crumbs)-1]
+	elided := false
+	for {
+		path := parents
+		if elided {
+			path = append([]string{"…"}, parents...)
+		}
+		prefix := ""
+		if len(path) > 0 {
+			prefix = strings.Join(path, breadcrumbSeparator) + breadcrumbSeparator
+		}
+		if len(parents) == 0 || lipgloss.Width(prefix+current) <= width {
+			return parentStyle.Render(prefix) + titleStyle.Render(current)
+		}
+		parents = parents[1:]
+		elided = true
+	}
+}
+
+// moveUp returns the index above selected, wrapping to the last item.
+func moveUp(selected, count int) int {
+	if count == 0 {
+		return 0
+	}
+	if selected > 0 {
+		return selected - 1
+	}
+	return count - 1
+}
+
+// moveDown returns the index below selected, wrapping to the first item.
+func moveDown(selected, count int) int {
+	if selected < count-1 {
+		return selected + 1
+	}
+	return 0
+}
+
 // repeatChar repeats a character n times.
 func repeatChar(ch rune, n int) string {
 	if n <= 0 {
@@ -0,0 +1,55 @@
+package modal
+
+import (
+	"testing"
+
+	"github.com/pxp/hub-tui/internal/client"
+	"github.com/pxp/hub-tui/internal/ui/keys"
+)
+
+func TestMoveUpDown(t *testing.T) {
+	tests := []struct {
+		name     string
+		selected int
+		count    int
+		wantUp   int
+		wantDown int
+	}{
+		{"empty", 0, 0, 0, 0},
+		{"single item", 0, 1, 0, 0},
+		{"first of many", 0, 5, 4, 1},
+		{"middle of many", 2, 5, 1, 3},
+		{"last of many", 4, 5, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moveUp(tt.selected, tt.count); got != tt.wantUp {
+				t.Errorf("moveUp(%d, %d) = %d, want %d", tt.selected, tt.count, got, tt.wantUp)
+			}
+			if got := moveDown(tt.selected, tt.count); got != tt.wantDown {
+				t.Errorf("moveDown(%d, %d) = %d, want %d", tt.selected, tt.count, got, tt.wantDown)
+			}
+		})
+	}
+}
+
+func TestStatePassesKeymap(t *testing.T) {
+	k, err := keys.New(map[string]string{"list_down": "n"})
+	if err != nil {
+		t.Fatalf("keys.New: %v", err)
+	}
+	s := NewState()
+	s.SetKeymap(k)
+
+	wm := NewWorkflowsModal(client.NewMock())
+	s.Open(runCmd(t, wm, wm.Init()))
+
+	s.Update(keyMsg("j"))
+	if wm.selected != 0 {
+		t.Errorf("j moved the selection to %d after list_down was rebound", wm.selected)
+	}
+	s.Update(keyMsg("n"))
+	if wm.selected != 1 {
+		t.Errorf("selected = %d after n, want 1", wm.selected)
+	}
+}
@@ -8,23 +8,44 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/pxp/hub-tui/internal/client"
+	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
+// modulesClient is the part of the hub-core API used by ModulesModal.
+type modulesClient interface {
+	ListModules() ([]client.Module, error)
+	EnableModule(name string) error
+	DisableModule(name string) error
+}
+
 // ModulesModal displays and manages modules.
 type ModulesModal struct {
-	client   *client.Client
+	client   modulesClient
 	modules  []client.Module
 	selected int
 	loading  bool
 	error    string
+	spinner  *components.Spinner
+	width    int
+	rows     listHitMap // Content line of each module, for mouse clicks
+	clicks   clickTracker
+	keys     *keys.Keymap
+
+	// Toggles shown optimistically while the server confirms them
+	pending     map[string]bool // Module name -> toggle in flight
+	toggleError string
 }
 
 // NewModulesModal creates a new modules modal.
-func NewModulesModal(c *client.Client) *ModulesModal {
+func NewModulesModal(c modulesClient) *ModulesModal {
 	return &ModulesModal{
 		client:  c,
 		loading: true,
+		spinner: components.NewSpinner(),
+		pending: make(map[string]bool),
+		keys:    keys.Default(),
 	}
 }
 
@@ -53,25 +74,59 @@ func (m *ModulesModal) loadModules() tea.Cmd {
 	}
 }
 
+// toggleModule flips the selected module right away and asks the server to
+// follow; handleToggled rolls it back if the request fails.
 func (m *ModulesModal) toggleModule() tea.Cmd {
 	if len(m.modules) == 0 {
 		return nil
 	}
-	mod := m.modules[m.selected]
+	mod := &m.modules[m.selected]
+	if m.pending[mod.Name] {
+		return nil
+	}
+	mod.Enabled = !mod.Enabled
+	m.pending[mod.Name] = true
+	m.toggleError = ""
+
+	name, enabled := mod.Name, mod.Enabled
 	return func() tea.Msg {
 		var err error
-		if mod.Enabled {
-			err = m.client.DisableModule(mod.Name)
+		if enabled {
+			err = m.client.EnableModule(name)
 		} else {
-			err = m.client.EnableModule(mod.Name)
+			err = m.client.DisableModule(name)
 		}
-		return ModuleToggledMsg{Name: mod.Name, Enabled: !mod.Enabled, Error: err}
+		return ModuleToggledMsg{Name: name, Enabled: enabled, Error: err}
 	}
 }
 
+// handleToggled settles a toggle, restoring the previous state on failure.
+func (m *ModulesModal) handleToggled(msg ModuleToggledMsg) {
+	delete(m.pending, msg.Name)
+	if msg.Error == nil {
+		return
+	}
+	for i := range m.modules {
+		if m.modules[i].Name == msg.Name {
+			m.modules[i].Enabled = !msg.Enabled
+			break
+		}
+	}
+	m.toggleError = fmt.Sprintf("Could not toggle %s: %v", msg.Name, msg.Error)
+}
+
+// IsLoading returns true while modules are being fetched.
+func (m *ModulesModal) IsLoading() bool {
+	return m.loading
+}
+
 // Update handles input.
 func (m *ModulesModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	switch msg := msg.(type) {
+	case components.SpinnerTickMsg:
+		m.spinner.Advance()
+		return m, nil
+
 	case ModulesLoadedMsg:
 		m.loading = false
 		if msg.Error != nil {
@@ -83,39 +138,38 @@ func (m *ModulesModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 		return m, nil
 
 	case ModuleToggledMsg:
-		if msg.Error != nil {
-			m.error = msg.Error.Error()
-		} else {
-			// Update local state
-			for i, mod := range m.modules {
-				if mod.Name == msg.Name {
-					m.modules[i].Enabled = msg.Enabled
-					break
-				}
+		m.handleToggled(msg)
+		return m, nil
+
+	case tea.MouseMsg:
+		line, ok := leftClickLine(msg)
+		if !ok || m.loading {
+			return m, nil
+		}
+		if i, ok := m.rows.itemAt(line); ok {
+			m.selected = i
+			if m.clicks.click(i) {
+				return m, m.toggleModule()
 			}
-			m.error = ""
 		}
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case msg.String() == "esc":
 			return nil, nil // Close modal
-		case "up", "k":
-			if m.selected > 0 {
-				m.selected--
-			}
-		case "down", "j":
-			if m.selected < len(m.modules)-1 {
-				m.selected++
-			}
-		case "enter":
+		case m.keys.Is(msg, keys.ListUp):
+			m.selected = moveUp(m.selected, len(m.modules))
+		case m.keys.Is(msg, keys.ListDown):
+			m.selected = moveDown(m.selected, len(m.modules))
+		case msg.String() == "enter":
 			if !m.loading && len(m.modules) > 0 {
 				return m, m.toggleModule()
 			}
-		case "r":
+		case msg.String() == "r":
 			m.loading = true
 			m.error = ""
+			m.toggleError = ""
 			return m, m.loadModules()
 		}
 	}
@@ -127,29 +181,36 @@ func (m *ModulesModal) Title() string {
 	return "Modules"
 }
 
+// SetKeymap sets the key bindings.
+func (m *ModulesModal) SetKeymap(k *keys.Keymap) {
+	m.keys = k
+}
+
+// SetWidth sets the available content width for wrapping.
+func (m *ModulesModal) SetWidth(width int) {
+	m.width = width
+}
+
 // View renders the modal content.
 func (m *ModulesModal) View() string {
+	m.rows.reset()
 	if m.loading {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("Loading modules...")
+		return m.spinner.View("Loading modules...")
 	}
 
 	if m.error != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 		hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
-			errorStyle.Render("Error: "+m.error),
+			renderError("Error: "+m.error, m.width, 0),
 			"",
 			hintStyle.Render("[r] Retry"),
 		)
 	}
 
 	if len(m.modules) == 0 {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("No modules found.")
+		return emptyState("No modules found.",
+			"[r] Refresh, or run /refresh if hub-core was just updated.")
 	}
 
 	var lines []string
@@ -159,6 +220,7 @@ func (m *ModulesModal) View() string {
 	selectedStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
 	normalStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
 	descStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	pendingStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary).Italic(true)
 
 	for i, mod := range m.modules {
 		// Status indicator
@@ -187,10 +249,18 @@ func (m *ModulesModal) View() string {
 			}
 			line += strings.Repeat(" ", padding) + descStyle.Render(mod.Description)
 		}
+		if m.pending[mod.Name] {
+			line += pendingStyle.Render("  saving…")
+		}
 
+		m.rows.set(len(lines), i)
 		lines = append(lines, line)
 	}
 
+	if m.toggleError != "" {
+		lines = append(lines, "", renderError(m.toggleError, m.width, 2))
+	}
+
 	// Add legend and hints
 	lines = append(lines, "")
 	legendStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
@@ -0,0 +1,69 @@
+package modal
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickInterval is the longest gap between two clicks on the same
+// item that still counts as a double-click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// modalHeaderLines is how many lines the modal box draws above its content:
+// the top border, the title bar and a blank line.
+const modalHeaderLines = 3
+
+// leftClickLine returns the content line of a left-button press.
+func leftClickLine(msg tea.MouseMsg) (int, bool) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return 0, false
+	}
+	return msg.Y, true
+}
+
+// listHitMap records which content line each list item was rendered on,
+// so clicks can be mapped back to items regardless of the list layout.
+// Views call reset before rendering and set for every item line.
+type listHitMap struct {
+	items map[int]int // Content line -> item index
+}
+
+// reset forgets the previous layout.
+func (h *listHitMap) reset() {
+	h.items = make(map[int]int)
+}
+
+// set records that item was rendered on content line.
+func (h *listHitMap) set(line, item int) {
+	if h.items == nil {
+		h.reset()
+	}
+	h.items[line] = item
+}
+
+// itemAt returns the item rendered on content line, if any.
+func (h listHitMap) itemAt(line int) (int, bool) {
+	item, ok := h.items[line]
+	return item, ok
+}
+
+// clickTracker detects double-clicks on list items.
+type clickTracker struct {
+	item int
+	at   time.Time
+}
+
+// click records a click on item and returns true if it completes a double-click.
+func (c *clickTracker) click(item int) bool {
+	now := time.Now()
+	double := item == c.item && !c.at.IsZero() && now.Sub(c.at) <= doubleClickInterval
+	if double {
+		// A third click starts over
+		c.at = time.Time{}
+	} else {
+		c.item = item
+		c.at = now
+	}
+	return double
+}
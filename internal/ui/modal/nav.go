@@ -0,0 +1,57 @@
+package modal
+
+// NavigableModal is an optional interface for modals with nested views.
+// State shows the path to the current view as a breadcrumb in the title
+// bar when it's more than one level deep.
+type NavigableModal interface {
+	Modal
+	Breadcrumb() []string
+}
+
+// ViewStack records the path through a modal's nested views, so Esc can
+// return one level at a time and the title bar can show the path.
+type ViewStack[V comparable] struct {
+	views []V
+}
+
+// NewViewStack returns a stack starting at the root view.
+func NewViewStack[V comparable](root V) *ViewStack[V] {
+	return &ViewStack[V]{views: []V{root}}
+}
+
+// Push opens view on top of the current one. Pushing the current view
+// again does nothing.
+func (s *ViewStack[V]) Push(view V) {
+	if s.Current() == view {
+		return
+	}
+	s.views = append(s.views, view)
+}
+
+// Pop returns to the previous view and returns it. The root view is never
+// popped.
+func (s *ViewStack[V]) Pop() V {
+	if len(s.views) > 1 {
+		s.views = s.views[:len(s.views)-1]
+	}
+	return s.Current()
+}
+
+// PopTo returns to view if it's on the stack, or to the root otherwise,
+// and returns the view now current.
+func (s *ViewStack[V]) PopTo(view V) V {
+	for len(s.views) > 1 && s.Current() != view {
+		s.views = s.views[:len(s.views)-1]
+	}
+	return s.Current()
+}
+
+// Current returns the view on top of the stack.
+func (s *ViewStack[V]) Current() V {
+	return s.views[len(s.views)-1]
+}
+
+// Views returns the path from the root to the current view.
+func (s *ViewStack[V]) Views() []V {
+	return s.views
+}
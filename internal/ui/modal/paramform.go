@@ -2,15 +2,19 @@ package modal
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/pxp/hub-tui/internal/client"
 	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
@@ -29,25 +33,49 @@ type ParamFormModal struct {
 	schema *client.ParamSchema
 	form   *components.Form
 	width  int
+	keys   *keys.Keymap
+
+	jsonErrors map[string]bool // Object fields showing a JSON parse error
+	confirm    *components.Confirmation
 }
 
 // NewParamFormModal creates a modal from an API schema.
 func NewParamFormModal(target string, schema *client.ParamSchema) *ParamFormModal {
-	fields := schemaToFormFields(schema.Params)
+	return NewParamFormModalWithLast(target, schema, nil, 0)
+}
+
+// NewParamFormModalWithLast creates a modal from an API schema, pre-filling
+// fields the schema leaves empty with the values submitted last time.
+// Remembered values that no longer fit the field's type are ignored.
+// confirmTimeout sets the double-press discard window (zero uses the default).
+func NewParamFormModalWithLast(target string, schema *client.ParamSchema, last map[string]interface{}, confirmTimeout time.Duration) *ParamFormModal {
+	fields := schemaToFormFields(schema.Params, last)
 	form := components.NewForm(schema.Title, fields)
 
 	return &ParamFormModal{
-		target: target,
-		schema: schema,
-		form:   form,
+		target:     target,
+		schema:     schema,
+		form:       form,
+		jsonErrors: make(map[string]bool),
+		confirm:    components.NewConfirmation().WithTimeout(confirmTimeout),
+		keys:       keys.Default(),
 	}
 }
 
-// schemaToFormFields converts API param fields to form fields.
-func schemaToFormFields(params []client.ParamField) []components.FormField {
+// schemaToFormFields converts API param fields to form fields. Fields
+// without a value from the API take their value from last, if it has one
+// of the right type, and otherwise the param's default.
+func schemaToFormFields(params []client.ParamField, last map[string]interface{}) []components.FormField {
 	var fields []components.FormField
 
 	for _, p := range params {
+		if v, ok := last[p.Name]; ok && p.Value == nil && matchesParamType(v, p.Type) {
+			p.Value = v
+		}
+		if p.Value == nil {
+			p.Value = p.Default
+		}
+
 		field := components.FormField{
 			Label:       humanize(p.Name),
 			Key:         p.Name,
@@ -58,11 +86,19 @@ func schemaToFormFields(params []client.ParamField) []components.FormField {
 		}
 
 		// Set field type based on param type
-		switch p.Type {
-		case "boolean":
+		switch {
+		case len(p.Enum) > 0 && p.Type != "boolean" && p.Type != "array" && p.Type != "object":
+			// Constrained values pick from a list, defaulting to the first
+			field.Type = components.FieldSelect
+			field.Options = p.Enum
+			field.Value = p.Enum[0]
+			if v := valueToString(p.Value); slices.Contains(p.Enum, v) {
+				field.Value = v
+			}
+		case p.Type == "boolean":
 			field.Type = components.FieldCheckbox
 			field.Checked = valueToBool(p.Value)
-		case "array", "object":
+		case p.Type == "array" || p.Type == "object":
 			field.Type = components.FieldTextArea
 			field.Value = valueToTextArea(p.Value, p.Type)
 		default: // string, number
@@ -70,12 +106,44 @@ func schemaToFormFields(params []client.ParamField) []components.FormField {
 			field.Value = valueToString(p.Value)
 		}
 
+		// Ctrl+D resets to the schema's default rather than the pre-filled value
+		if p.Default != nil {
+			switch field.Type {
+			case components.FieldCheckbox:
+				field.Default = strconv.FormatBool(valueToBool(p.Default))
+			case components.FieldTextArea:
+				field.Default = valueToTextArea(p.Default, p.Type)
+			default:
+				field.Default = valueToString(p.Default)
+			}
+		}
+
 		fields = append(fields, field)
 	}
 
 	return fields
 }
 
+// matchesParamType reports whether a remembered value still fits a param
+// of the given type. Values are as decoded from JSON.
+func matchesParamType(v interface{}, paramType string) bool {
+	switch v.(type) {
+	case bool:
+		return paramType == "boolean"
+	case float64:
+		return paramType == "number"
+	case []interface{}:
+		return paramType == "array"
+	case map[string]interface{}:
+		return paramType == "object"
+	case string:
+		return paramType != "boolean" && paramType != "number" &&
+			paramType != "array" && paramType != "object"
+	default:
+		return false
+	}
+}
+
 // humanize converts snake_case to Title Case.
 func humanize(s string) string {
 	words := strings.Split(s, "_")
@@ -169,16 +237,34 @@ func (m *ParamFormModal) Init() tea.Cmd {
 // Update implements Modal.
 func (m *ParamFormModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	switch msg := msg.(type) {
+	case components.ConfirmationExpiredMsg:
+		m.confirm.HandleExpired(msg)
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case msg.String() == "esc":
+			// Discarding edits takes a second press
+			if m.form.IsDirty() {
+				if execute, cmd := m.confirm.Check("discard", ""); !execute {
+					return m, cmd
+				}
+			}
 			// Cancel - return nil to close modal
 			return nil, func() tea.Msg { return ParamFormCancelMsg{} }
 
-		case "ctrl+s":
-			// Validate required fields
+		case m.keys.Is(msg, keys.Save):
+			// Validate required fields and JSON objects
 			m.form.ClearErrors()
 			errors := m.form.ValidateRequired()
+			for _, field := range m.form.Fields {
+				if _, ok := errors[field.Key]; ok || field.ParamType != "object" {
+					continue
+				}
+				if errMsg := objectParamError(field.Value); errMsg != "" {
+					errors[field.Key] = errMsg
+					m.jsonErrors[field.Key] = true
+				}
+			}
 			if len(errors) > 0 {
 				for key, errMsg := range errors {
 					m.form.SetFieldError(key, errMsg)
@@ -196,13 +282,65 @@ func (m *ParamFormModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 			}
 		}
 
-		// Forward to form
+		// Forward to form, checking an object field's JSON once focus
+		// leaves it
+		m.confirm.Clear()
+		left := m.focusedObjectField()
 		m.form.Update(msg)
+		if left != "" && !m.form.IsFieldFocused(left) {
+			m.validateObjectField(left)
+		}
 	}
 
 	return m, nil
 }
 
+// focusedObjectField returns the key of the focused object field, or "".
+func (m *ParamFormModal) focusedObjectField() string {
+	for _, field := range m.form.Fields {
+		if field.ParamType == "object" && m.form.IsFieldFocused(field.Key) {
+			return field.Key
+		}
+	}
+	return ""
+}
+
+// validateObjectField shows or clears the JSON error on an object field.
+// Errors from the server are left alone unless replaced by a JSON error.
+func (m *ParamFormModal) validateObjectField(key string) {
+	errMsg := objectParamError(m.form.GetFieldValue(key))
+	if errMsg != "" {
+		m.form.SetFieldError(key, errMsg)
+		m.jsonErrors[key] = true
+	} else if m.jsonErrors[key] {
+		m.form.SetFieldError(key, "")
+		delete(m.jsonErrors, key)
+	}
+}
+
+// objectParamError returns why value isn't a valid JSON object, including
+// the line of a syntax error, or "" if it is one. Empty values are valid.
+func objectParamError(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	var obj map[string]interface{}
+	err := json.Unmarshal([]byte(value), &obj)
+	if err == nil {
+		return ""
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := 1 + strings.Count(value[:syntaxErr.Offset], "\n")
+		return fmt.Sprintf("Invalid JSON on line %d: %s", line, syntaxErr.Error())
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return "Must be a JSON object"
+	}
+	return "Invalid JSON: " + err.Error()
+}
+
 // buildParams converts form values to typed params for API submission.
 func (m *ParamFormModal) buildParams() map[string]interface{} {
 	params := make(map[string]interface{})
@@ -270,8 +408,14 @@ func (m *ParamFormModal) View() string {
 	}
 
 	// Form
+	m.form.SetWidth(m.width)
 	lines = append(lines, m.form.View())
 
+	if m.confirm.IsPending("discard", "") {
+		warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+		lines = append(lines, "", warnStyle.Render("Discard changes? Press Esc again"))
+	}
+
 	return strings.Join(lines, "\n")
 }
 
@@ -285,6 +429,11 @@ func (m *ParamFormModal) IsFormModal() bool {
 	return true
 }
 
+// SetKeymap sets the key bindings.
+func (m *ParamFormModal) SetKeymap(k *keys.Keymap) {
+	m.keys = k
+}
+
 // SetWidth sets the available width for the modal.
 func (m *ParamFormModal) SetWidth(width int) {
 	m.width = width
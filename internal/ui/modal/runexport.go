@@ -0,0 +1,68 @@
+package modal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pxp/hub-tui/internal/client"
+)
+
+// RunExportedMsg is sent when an export started with ExportRun finishes.
+type RunExportedMsg struct {
+	RunID string
+	Path  string // File the run was written to
+	Error error
+}
+
+// runGetter is the part of the hub-core API used to export a run.
+type runGetter interface {
+	GetRun(id string) (*client.Run, error)
+}
+
+// ExportRun returns a command that fetches a run and writes it as
+// indented JSON to run-<id>.json in the working directory, replacing any
+// earlier export of the same run.
+func ExportRun(c runGetter, id string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := exportRun(c, id)
+		return RunExportedMsg{RunID: id, Path: path, Error: err}
+	}
+}
+
+// exportRun implements ExportRun, returning the absolute path written.
+func exportRun(c runGetter, id string) (string, error) {
+	run, err := c.GetRun(id)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return "", fmt.Errorf("run %s not found; it may have been cleaned up by hub-core", id)
+		}
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	// Run IDs become part of the file name, so keep them to one path element
+	name := "run-" + strings.NewReplacer("/", "_", `\`, "_").Replace(id) + ".json"
+	path, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			err = pathErr.Err
+		}
+		return "", fmt.Errorf("could not write %s: %w", path, err)
+	}
+	return path, nil
+}
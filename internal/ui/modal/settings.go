@@ -1,15 +1,19 @@
 package modal
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/pxp/hub-tui/internal/client"
 	"github.com/pxp/hub-tui/internal/config"
 	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
+	"github.com/pxp/hub-tui/internal/version"
 )
 
 // SettingsSavedMsg is sent when settings are saved.
@@ -18,24 +22,58 @@ type SettingsSavedMsg struct {
 	Error  error
 }
 
+// ServerInfoLoadedMsg is sent when the server version has been fetched.
+type ServerInfoLoadedMsg struct {
+	Info  *client.ServerInfo
+	Error error
+}
+
 // RefreshConnectionMsg is sent when the user requests a connection refresh.
 type RefreshConnectionMsg struct{}
 
+// settingsClient is the part of the hub-core API used by SettingsModal.
+type settingsClient interface {
+	ServerInfo() (*client.ServerInfo, error)
+}
+
 // SettingsModal displays and edits configuration.
 type SettingsModal struct {
+	client     settingsClient
 	config     *config.Config
 	connected  bool
 	refreshing bool
 	editing    bool
 	form       *components.Form
+	confirm    *components.Confirmation // Discarding edits
 	error      string
+	width      int
+	keys       *keys.Keymap
+
+	// Server version, loaded on open
+	serverInfo  *client.ServerInfo
+	loadingInfo bool
+
+	// Read-only config JSON view
+	viewingConfig bool
+	configScroll  int // First visible line
+	configHeight  int // Visible lines
+
+	notice string // Result of the last copy, shown below the settings
 }
 
+// defaultConfigViewHeight is the config view height before the modal is sized.
+const defaultConfigViewHeight = 15
+
 // NewSettingsModal creates a new settings modal.
-func NewSettingsModal(cfg *config.Config, connected bool) *SettingsModal {
+func NewSettingsModal(c settingsClient, cfg *config.Config, connected bool) *SettingsModal {
 	return &SettingsModal{
-		config:    cfg,
-		connected: connected,
+		client:       c,
+		config:       cfg,
+		connected:    connected,
+		loadingInfo:  c != nil,
+		configHeight: defaultConfigViewHeight,
+		confirm:      components.NewConfirmation().WithTimeout(cfg.ConfirmTimeout()),
+		keys:         keys.Default(),
 	}
 }
 
@@ -47,12 +85,48 @@ func (m *SettingsModal) SetConnected(connected bool) {
 
 // Init initializes the modal.
 func (m *SettingsModal) Init() tea.Cmd {
-	return nil
+	if m.client == nil {
+		return nil
+	}
+	return m.loadServerInfo()
+}
+
+// loadServerInfo fetches the server version.
+func (m *SettingsModal) loadServerInfo() tea.Cmd {
+	return func() tea.Msg {
+		info, err := m.client.ServerInfo()
+		return ServerInfoLoadedMsg{Info: info, Error: err}
+	}
+}
+
+// IsCapturingInput returns true while the settings form is being edited.
+func (m *SettingsModal) IsCapturingInput() bool {
+	return m.editing
 }
 
 // Update handles input.
 func (m *SettingsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	switch msg := msg.(type) {
+	case ServerInfoLoadedMsg:
+		m.loadingInfo = false
+		// Errors just leave the version unknown
+		m.serverInfo = msg.Info
+		return m, nil
+
+	case components.ConfirmationExpiredMsg:
+		m.confirm.HandleExpired(msg)
+		return m, nil
+
+	case components.ClipboardCopiedMsg:
+		if msg.Error != nil {
+			m.notice = ""
+			m.error = "Copy failed: " + msg.Error.Error()
+		} else {
+			m.error = ""
+			m.notice = "Config path copied to clipboard."
+		}
+		return m, nil
+
 	case SettingsSavedMsg:
 		if msg.Error != nil {
 			m.error = msg.Error.Error()
@@ -62,6 +136,8 @@ func (m *SettingsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 			m.editing = false
 			m.form = nil
 			m.error = ""
+			// The app re-checks the connection after a save
+			m.refreshing = true
 		}
 		return m, nil
 
@@ -69,6 +145,9 @@ func (m *SettingsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 		if m.editing {
 			return m.updateEditing(msg)
 		}
+		if m.viewingConfig {
+			return m.updateConfigView(msg)
+		}
 		return m.updateViewing(msg)
 	}
 	return m, nil
@@ -95,46 +174,110 @@ func (m *SettingsModal) updateViewing(msg tea.KeyMsg) (Modal, tea.Cmd) {
 		// Refresh connection
 		m.refreshing = true
 		return m, func() tea.Msg { return RefreshConnectionMsg{} }
+	case "c":
+		// Copy config path
+		m.notice = ""
+		configPath, err := m.config.Path()
+		if err != nil {
+			m.error = err.Error()
+			return m, nil
+		}
+		return m, components.CopyToClipboard(configPath)
+	case "v":
+		// Show effective config
+		m.viewingConfig = true
+		m.configScroll = 0
+		m.notice = ""
 	}
 	return m, nil
 }
 
+// updateConfigView handles input in the config JSON view.
+func (m *SettingsModal) updateConfigView(msg tea.KeyMsg) (Modal, tea.Cmd) {
+	switch key := msg.String(); {
+	case key == "esc" || key == "v":
+		m.viewingConfig = false
+	case m.keys.Is(msg, keys.ListUp):
+		m.configScroll--
+	case m.keys.Is(msg, keys.ListDown):
+		m.configScroll++
+	case key == "pgup":
+		m.configScroll -= m.configHeight
+	case key == "pgdown":
+		m.configScroll += m.configHeight
+	}
+	m.clampConfigScroll(len(m.configLines()))
+	return m, nil
+}
+
+// configLines returns the lines of the effective config JSON.
+func (m *SettingsModal) configLines() []string {
+	data, err := m.config.JSON()
+	if err != nil {
+		return []string{"Error: " + err.Error()}
+	}
+	return strings.Split(data, "\n")
+}
+
+// clampConfigScroll keeps the config scroll offset within the content bounds.
+func (m *SettingsModal) clampConfigScroll(total int) int {
+	maxScroll := total - m.configHeight
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.configScroll > maxScroll {
+		m.configScroll = maxScroll
+	}
+	if m.configScroll < 0 {
+		m.configScroll = 0
+	}
+	return m.configScroll
+}
+
 // updateEditing handles input in edit mode.
 func (m *SettingsModal) updateEditing(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch {
+	case msg.String() == "esc":
+		// Discarding edits takes a second press
+		if m.form.IsDirty() {
+			if execute, cmd := m.confirm.Check("discard", ""); !execute {
+				return m, cmd
+			}
+		}
 		// Cancel edit mode
 		m.editing = false
 		m.form = nil
 		m.error = ""
 		return m, nil
-	case "ctrl+s":
+	case m.keys.Is(msg, keys.Save):
 		// Save settings
 		return m, m.saveSettings()
 	}
 
 	// Pass to form
+	m.confirm.Clear()
 	m.form.Update(msg)
 	return m, nil
 }
 
 // saveSettings saves the settings to the config file.
 func (m *SettingsModal) saveSettings() tea.Cmd {
-	serverURL := m.form.GetFieldValue("server_url")
+	serverURL, err := client.NormalizeServerURL(m.form.GetFieldValue("server_url"))
+	if err != nil {
+		m.error = err.Error()
+		return nil
+	}
 	return func() tea.Msg {
-		// Create updated config (preserve token info)
-		newConfig := &config.Config{
-			ServerURL: strings.TrimSpace(serverURL),
-			Token:     m.config.Token,
-			TokenExp:  m.config.TokenExp,
-		}
+		// Copy the config so token info and other settings are preserved
+		newConfig := *m.config
+		newConfig.ServerURL = serverURL
 
 		// Save to disk
 		if err := newConfig.Save(); err != nil {
 			return SettingsSavedMsg{Error: err}
 		}
 
-		return SettingsSavedMsg{Config: newConfig}
+		return SettingsSavedMsg{Config: &newConfig}
 	}
 }
 
@@ -143,14 +286,66 @@ func (m *SettingsModal) Title() string {
 	return "Settings"
 }
 
+// SetKeymap sets the key bindings.
+func (m *SettingsModal) SetKeymap(k *keys.Keymap) {
+	m.keys = k
+}
+
+// SetWidth sets the available content width for wrapping.
+func (m *SettingsModal) SetWidth(width int) {
+	m.width = width
+}
+
+// SetHeight sets the available content height for the config view.
+func (m *SettingsModal) SetHeight(height int) {
+	// Reserve the blank line and hint line below the scrolled content
+	m.configHeight = height - 2
+	if m.configHeight < minScrollHeight {
+		m.configHeight = minScrollHeight
+	}
+}
+
 // View renders the settings content.
 func (m *SettingsModal) View() string {
 	if m.editing {
 		return m.viewEditing()
 	}
+	if m.viewingConfig {
+		return m.viewConfig()
+	}
 	return m.viewDisplay()
 }
 
+// viewConfig renders the scrollable config JSON.
+func (m *SettingsModal) viewConfig() string {
+	valueStyle := lipgloss.NewStyle().
+		Foreground(theme.TextPrimary)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextSecondary).
+		Italic(true)
+
+	lines := m.configLines()
+	scroll := m.clampConfigScroll(len(lines))
+	end := scroll + m.configHeight
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var visible []string
+	for _, line := range lines[scroll:end] {
+		visible = append(visible, valueStyle.Render(line))
+	}
+
+	hints := "[Esc] Back"
+	if len(lines) > m.configHeight {
+		hints += fmt.Sprintf("  [↑/↓] Scroll %d-%d of %d", scroll+1, end, len(lines))
+	}
+	visible = append(visible, "", hintStyle.Render(hints))
+
+	return strings.Join(visible, "\n")
+}
+
 // viewDisplay renders the read-only settings view.
 func (m *SettingsModal) viewDisplay() string {
 	labelStyle := lipgloss.NewStyle().
@@ -194,6 +389,18 @@ func (m *SettingsModal) viewDisplay() string {
 		labelStyle.Render("Status:")+connStatus,
 	)
 
+	// Server and client versions
+	var serverVersion string
+	if m.loadingInfo {
+		serverVersion = hintStyle.Render("Loading...")
+	} else {
+		serverVersion = valueStyle.Render(m.formatServerVersion())
+	}
+	lines = append(lines,
+		labelStyle.Render("Version:")+serverVersion,
+		labelStyle.Render("hub-tui:")+valueStyle.Render(version.String()),
+	)
+
 	lines = append(lines, "")
 
 	// Token expiry
@@ -206,13 +413,22 @@ func (m *SettingsModal) viewDisplay() string {
 	lines = append(lines, "")
 
 	// Config file location
-	configPath, _ := config.DefaultPath()
+	configPath, _ := m.config.Path()
 	lines = append(lines,
 		hintStyle.Render("Config: "+configPath),
 	)
 
+	if m.notice != "" {
+		lines = append(lines, "")
+		lines = append(lines, successStyle.Render(m.notice))
+	}
+	if m.error != "" {
+		lines = append(lines, "")
+		lines = append(lines, renderError("Error: "+m.error, m.width, 0))
+	}
+
 	lines = append(lines, "")
-	lines = append(lines, hintStyle.Render("[e] Edit  [r] Refresh"))
+	lines = append(lines, hintStyle.Render("[e] Edit  [r] Refresh  [c] Copy path  [v] View config"))
 
 	return strings.Join(lines, "\n")
 }
@@ -222,13 +438,13 @@ func (m *SettingsModal) viewEditing() string {
 	var lines []string
 
 	// Form
+	m.form.SetWidth(m.width)
 	lines = append(lines, m.form.View())
 
 	// Error message
 	if m.error != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 		lines = append(lines, "")
-		lines = append(lines, errorStyle.Render("Error: "+m.error))
+		lines = append(lines, renderError("Error: "+m.error, m.width, 0))
 	}
 
 	// Hints
@@ -236,11 +452,35 @@ func (m *SettingsModal) viewEditing() string {
 	hintStyle := lipgloss.NewStyle().
 		Foreground(theme.TextSecondary).
 		Italic(true)
-	lines = append(lines, hintStyle.Render("[Ctrl+S] Save  [Esc] Cancel"))
+	if m.confirm.IsPending("discard", "") {
+		warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+		lines = append(lines, warnStyle.Render("Discard changes? Press Esc again"))
+	} else {
+		lines = append(lines, hintStyle.Render("["+m.keys.Label(keys.Save)+"] Save  [Esc] Cancel"))
+	}
 
 	return strings.Join(lines, "\n")
 }
 
+// formatServerVersion formats the server version and build info.
+func (m *SettingsModal) formatServerVersion() string {
+	if m.serverInfo == nil || m.serverInfo.Version == "" {
+		return "unknown"
+	}
+
+	var build []string
+	if m.serverInfo.Commit != "" {
+		build = append(build, m.serverInfo.Commit)
+	}
+	if m.serverInfo.BuildDate != "" {
+		build = append(build, m.serverInfo.BuildDate)
+	}
+	if len(build) == 0 {
+		return m.serverInfo.Version
+	}
+	return m.serverInfo.Version + " (" + strings.Join(build, ", ") + ")"
+}
+
 // formatTokenExpiry formats the token expiry date.
 func (m *SettingsModal) formatTokenExpiry() string {
 	if m.config.TokenExp == "" {
@@ -0,0 +1,36 @@
+package modal
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pxp/hub-tui/internal/config"
+)
+
+func TestSettingsFormTakesQ(t *testing.T) {
+	var s State
+	sm := NewSettingsModal(nil, &config.Config{ServerURL: "http://hub.test"}, false)
+	s.Open(sm)
+
+	s.Update(keyMsg("e"))
+	if !sm.editing {
+		t.Fatal("e didn't start editing")
+	}
+	s.Update(keyMsg("q"))
+	if s.Active == nil {
+		t.Fatal("q closed the modal while editing")
+	}
+	if got := sm.form.GetFieldValue("server_url"); !strings.Contains(got, "q") {
+		t.Errorf("server URL = %q, want the typed q", got)
+	}
+
+	// Outside the form, q closes the modal again
+	s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	s.Update(keyMsg("q"))
+	if s.Active != nil {
+		t.Error("q didn't close the modal outside the form")
+	}
+}
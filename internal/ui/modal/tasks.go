@@ -1,9 +1,12 @@
 package modal
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/pxp/hub-tui/internal/client"
 	"github.com/pxp/hub-tui/internal/ui/components"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
@@ -45,9 +49,30 @@ func formatRunOutput(result *client.RunResult) string {
 	return result.Output
 }
 
+// formatRawResult renders the run result as indented JSON.
+func formatRawResult(result *client.RunResult) string {
+	if result == nil {
+		return "null"
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	return string(data)
+}
+
+// tasksClient is the part of the hub-core API used by TasksModal.
+type tasksClient interface {
+	ListRuns(filter *client.RunsFilter) (*client.RunsResponse, error)
+	GetRun(id string) (*client.Run, error)
+	GetRunWithRetry(id string, retry client.RunRetry) (*client.Run, error)
+	CancelRun(id string) error
+	DismissRun(id string) error
+}
+
 // TasksModal displays running, completed, and failed tasks.
 type TasksModal struct {
-	client           *client.Client
+	client           tasksClient
 	needsAttention   []TaskRun // All-time runs needing attention
 	running          []TaskRun // Today's running
 	completed        []TaskRun // Today's completed (needs_attention=false)
@@ -60,7 +85,41 @@ type TasksModal struct {
 	detailError string    // Error loading task details
 	view        tasksView
 	detailRun   *TaskRun // Run being viewed in detail
+	rawOutput   bool     // Show raw JSON result instead of formatted output
 	confirm     *components.Confirmation
+	spinner     *components.Spinner
+	width       int
+	keys        *keys.Keymap
+
+	// Detail view scrolling
+	detailScroll int // First visible line in the detail view
+	detailHeight int // Visible lines in the detail view
+
+	detailNotice string // Result of the last copy or export from the detail view
+
+	clockGen int // Identifies this modal's clock ticks
+
+	// Show exact timestamps instead of elapsed times in the list
+	absoluteTimes bool
+
+	// Select the first run needing attention once the list loads
+	focusAttention bool
+
+	// Dismissing every run needing attention at once
+	dismissingAll   int    // Runs being dismissed, 0 when idle
+	dismissAllError string // Summary of runs that failed to dismiss
+
+	// Text filter narrowing every section; the section slices above
+	// hold only the matching runs
+	unfiltered taskSections
+	filter     string
+	filtering  bool // Typing into the filter
+
+	// Today's runs are fetched in pages as the list is scrolled
+	runsCursor  string // Cursor for the next page of today's runs
+	runsHasMore bool   // Whether more of today's runs are available
+	loadingMore bool   // Fetching the next page of today's runs
+	pageGen     int    // Incremented per reload or filter change to discard stale pages
 
 	// Pagination state
 	completedPage    int
@@ -70,16 +129,33 @@ type TasksModal struct {
 
 	// History view state
 	history         []TaskRun       // Current page of history
-	historyPage     int             // Current page (0-indexed)
+	historyPager    *components.Paginator
 	historyTotal    int             // Total history items from API
-	historyHasMore  bool            // Whether more pages are available
-	historyCursors  map[int]string  // Cursor for each page (page number -> cursor)
 	previousView    tasksView       // View to return to from detail
 }
 
+// taskSections holds the runs of each section of the task list.
+type taskSections struct {
+	needsAttention []TaskRun
+	running        []TaskRun
+	completed      []TaskRun
+	failed         []TaskRun
+}
+
 const itemsPerPage = 5
+
+// defaultDetailHeight is the number of visible lines in the detail view
+// until the modal is given its real height.
+const defaultDetailHeight = 20
 const historyItemsPerPage = 15
 
+// tasksPageSize is how many of today's runs are fetched per request.
+const tasksPageSize = 50
+
+// loadMoreThreshold is how close to the end of the list the selection
+// gets before the next page of today's runs is fetched.
+const loadMoreThreshold = 2
+
 type tasksView int
 
 const (
@@ -89,13 +165,90 @@ const (
 )
 
 // NewTasksModal creates a new tasks modal that fetches fresh data from the API.
-func NewTasksModal(c *client.Client) *TasksModal {
+// confirmTimeout sets the double-press dismiss window (zero uses the default).
+func NewTasksModal(c tasksClient, confirmTimeout time.Duration) *TasksModal {
 	return &TasksModal{
 		client:  c,
 		loading: true,
 		view:    viewTasksList,
-		confirm: components.NewConfirmation(),
+		confirm: components.NewConfirmation().WithTimeout(confirmTimeout),
+		spinner: components.NewSpinner(),
+
+		detailHeight: defaultDetailHeight,
+		historyPager: components.NewPaginator(),
+		keys:         keys.Default(),
+	}
+}
+
+// NextNeedsAttention selects the next run needing attention, wrapping to the
+// first. Before the list has loaded, the first one is selected on load.
+func (m *TasksModal) NextNeedsAttention() {
+	if m.loading {
+		m.focusAttention = true
+		return
+	}
+	if len(m.needsAttention) == 0 {
+		return
+	}
+	m.view = viewTasksList
+	m.confirm.Clear()
+	// Needs-attention runs come first in allRuns
+	if m.selected >= 0 && m.selected < len(m.needsAttention)-1 {
+		m.selected++
+	} else {
+		m.selected = 0
+	}
+}
+
+// SetAbsoluteTimes sets whether the list shows exact timestamps.
+func (m *TasksModal) SetAbsoluteTimes(absolute bool) {
+	m.absoluteTimes = absolute
+}
+
+// formatRunTime formats a run start or end time in the list's time display.
+func (m *TasksModal) formatRunTime(t time.Time) string {
+	if m.absoluteTimes {
+		return formatTime(t)
+	}
+	return formatElapsed(t)
+}
+
+// applyFilter fills the visible sections with the runs matching the filter.
+func (m *TasksModal) applyFilter() {
+	m.needsAttention = filterRuns(m.unfiltered.needsAttention, m.filter)
+	m.running = filterRuns(m.unfiltered.running, m.filter)
+	m.completed = filterRuns(m.unfiltered.completed, m.filter)
+	m.failed = filterRuns(m.unfiltered.failed, m.filter)
+	m.completedTotal = len(m.completed)
+	m.failedTotal = len(m.failed)
+}
+
+// setFilter changes the filter, keeping the selected run selected if it still matches.
+func (m *TasksModal) setFilter(filter string) {
+	selectedID := m.selectedRunID()
+	m.filter = filter
+	m.discardPendingPage()
+	m.completedPage = 0
+	m.failedPage = 0
+	m.applyFilter()
+	m.selectRun(selectedID)
+}
+
+// filterRuns returns the runs whose workflow name or ID contains filter,
+// ignoring case.
+func filterRuns(runs []TaskRun, filter string) []TaskRun {
+	if filter == "" {
+		return runs
+	}
+	filter = strings.ToLower(filter)
+	var matched []TaskRun
+	for _, r := range runs {
+		if strings.Contains(strings.ToLower(r.Workflow), filter) ||
+			strings.Contains(strings.ToLower(r.ID), filter) {
+			matched = append(matched, r)
+		}
 	}
+	return matched
 }
 
 func (m *TasksModal) buildAllRuns() {
@@ -107,6 +260,45 @@ func (m *TasksModal) buildAllRuns() {
 	m.allRuns = append(m.allRuns, m.getFailedPage()...)
 }
 
+// selectedRunID returns the ID of the selected run in the list view, or "" if none.
+func (m *TasksModal) selectedRunID() string {
+	if m.selected < 0 || m.selected >= len(m.allRuns) {
+		return ""
+	}
+	return m.allRuns[m.selected].ID
+}
+
+// selectRun rebuilds the list and moves the selection to the run with the given ID,
+// paging the completed/failed sections to it if needed. If the run is gone the
+// current index is clamped instead.
+func (m *TasksModal) selectRun(id string) {
+	if id != "" {
+		for i, r := range m.completed {
+			if r.ID == id {
+				m.completedPage = i / itemsPerPage
+			}
+		}
+		for i, r := range m.failed {
+			if r.ID == id {
+				m.failedPage = i / itemsPerPage
+			}
+		}
+	}
+	m.buildAllRuns()
+
+	if id != "" {
+		for i, r := range m.allRuns {
+			if r.ID == id {
+				m.selected = i
+				return
+			}
+		}
+	}
+	if m.selected >= len(m.allRuns) {
+		m.selected = max(0, len(m.allRuns)-1)
+	}
+}
+
 func (m *TasksModal) getCompletedPage() []TaskRun {
 	start := m.completedPage * itemsPerPage
 	end := start + itemsPerPage
@@ -182,9 +374,28 @@ type TasksLoadedMsg struct {
 	Running        []TaskRun
 	Completed      []TaskRun
 	Failed         []TaskRun
+	NextCursor     string // Cursor for the next page of today's runs
+	HasMore        bool
 	Error          error
 }
 
+// TasksPageLoadedMsg is sent when a further page of today's runs is loaded.
+type TasksPageLoadedMsg struct {
+	Running    []TaskRun
+	Completed  []TaskRun
+	Failed     []TaskRun
+	NextCursor string
+	HasMore    bool
+	Gen        int // Page request generation; stale pages are dropped
+	Error      error
+}
+
+// TaskTimeFormatChangedMsg is sent when the list switches between relative
+// and absolute times, so the choice can be saved.
+type TaskTimeFormatChangedMsg struct {
+	Absolute bool
+}
+
 // TaskDetailLoadedMsg is sent when full run details are loaded.
 type TaskDetailLoadedMsg struct {
 	Run   *TaskRun
@@ -196,12 +407,30 @@ type TaskCancelRequestMsg struct {
 	RunID string
 }
 
+// TaskRerunRequestMsg is sent when a finished run's workflow should run
+// again.
+type TaskRerunRequestMsg struct {
+	Workflow string
+}
+
 // TaskDismissedMsg is sent when a task is dismissed.
 type TaskDismissedMsg struct {
 	RunID string
 	Error error
 }
 
+// TasksDismissedMsg is sent when a dismiss-all finishes. Failed maps the
+// runs that couldn't be dismissed to their errors; Error is set only for
+// auth failures.
+type TasksDismissedMsg struct {
+	Dismissed int
+	Failed    map[string]error
+	Error     error
+}
+
+// dismissWorkers bounds how many runs are dismissed at once.
+const dismissWorkers = 4
+
 // HistoryLoadedMsg is sent when history is loaded.
 type HistoryLoadedMsg struct {
 	Runs       []TaskRun
@@ -214,14 +443,42 @@ type HistoryLoadedMsg struct {
 
 // Init initializes the modal.
 func (m *TasksModal) Init() tea.Cmd {
+	tasksClockGen++
+	m.clockGen = tasksClockGen
+
 	// If we already have state, no need to load
 	if !m.loading {
-		return nil
+		return m.clockTick()
 	}
-	return m.loadTasks()
+	return tea.Batch(m.loadTasks(), m.clockTick())
+}
+
+// tasksClockInterval is how often the open modal redraws so relative
+// times like "5 min ago" stay current.
+const tasksClockInterval = 30 * time.Second
+
+// tasksClockGen numbers each opened tasks modal, so ticks scheduled by a
+// modal that has since closed don't keep a second clock running.
+var tasksClockGen int
+
+// TasksClockTickMsg redraws the tasks modal to refresh relative times.
+// The app only delivers it while a modal is open, which stops the clock
+// once the tasks modal closes.
+type TasksClockTickMsg struct {
+	Gen int
+}
+
+// clockTick schedules the next redraw.
+func (m *TasksModal) clockTick() tea.Cmd {
+	gen := m.clockGen
+	return tea.Tick(tasksClockInterval, func(time.Time) tea.Msg {
+		return TasksClockTickMsg{Gen: gen}
+	})
 }
 
 func (m *TasksModal) loadTasks() tea.Cmd {
+	// A page requested before the reload would be appended to the new list
+	m.discardPendingPage()
 	return func() tea.Msg {
 		today := time.Now().Format("2006-01-02")
 
@@ -234,9 +491,10 @@ func (m *TasksModal) loadTasks() tea.Cmd {
 			return TasksLoadedMsg{Error: err}
 		}
 
-		// Fetch today's runs
+		// Fetch the first page of today's runs
 		todayResp, err := m.client.ListRuns(&client.RunsFilter{
 			Since: today,
+			Limit: tasksPageSize,
 		})
 		if err != nil {
 			return TasksLoadedMsg{Error: err}
@@ -249,36 +507,102 @@ func (m *TasksModal) loadTasks() tea.Cmd {
 			attentionIDs[r.ID] = true
 			needsAttention = append(needsAttention, clientRunToTaskRun(r))
 		}
-
-		// Build today's lists, excluding items already in needs attention
-		var running, completed, failed []TaskRun
-		for _, r := range todayResp.Runs {
-			if attentionIDs[r.ID] {
-				continue // Already in needs attention section
-			}
-			tr := clientRunToTaskRun(r)
-			if r.Status == "running" {
-				running = append(running, tr)
-			} else if isRunSuccess(r) {
-				completed = append(completed, tr)
-			} else {
-				failed = append(failed, tr)
-			}
-		}
-
-		// Sort each category by most recent first
 		sortByMostRecent(needsAttention)
-		sortByMostRecent(running)
-		sortByMostRecent(completed)
-		sortByMostRecent(failed)
+
+		running, completed, failed := splitTodayRuns(todayResp.Runs, attentionIDs)
 
 		return TasksLoadedMsg{
 			NeedsAttention: needsAttention,
 			Running:        running,
 			Completed:      completed,
 			Failed:         failed,
+			NextCursor:     todayResp.Pagination.NextCursor,
+			HasMore:        todayResp.Pagination.HasMore,
+		}
+	}
+}
+
+// discardPendingPage makes any page of today's runs still being fetched
+// be ignored when it arrives.
+func (m *TasksModal) discardPendingPage() {
+	m.pageGen++
+	m.loadingMore = false
+}
+
+// loadMoreTasks fetches the next page of today's runs.
+func (m *TasksModal) loadMoreTasks() tea.Cmd {
+	cursor := m.runsCursor
+	gen := m.pageGen
+	attentionIDs := make(map[string]bool)
+	for _, r := range m.unfiltered.needsAttention {
+		attentionIDs[r.ID] = true
+	}
+
+	return func() tea.Msg {
+		resp, err := m.client.ListRuns(&client.RunsFilter{
+			Since:  time.Now().Format("2006-01-02"),
+			Limit:  tasksPageSize,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return TasksPageLoadedMsg{Gen: gen, Error: err}
+		}
+
+		running, completed, failed := splitTodayRuns(resp.Runs, attentionIDs)
+		return TasksPageLoadedMsg{
+			Gen:        gen,
+			Running:    running,
+			Completed:  completed,
+			Failed:     failed,
+			NextCursor: resp.Pagination.NextCursor,
+			HasMore:    resp.Pagination.HasMore,
+		}
+	}
+}
+
+// maybeLoadMore fetches the next page of today's runs once the selection
+// nears the end of the list or the last page of its section.
+func (m *TasksModal) maybeLoadMore() tea.Cmd {
+	if !m.runsHasMore || m.loadingMore || m.loading || m.runsCursor == "" {
+		return nil
+	}
+
+	nearEnd := m.selected >= len(m.allRuns)-loadMoreThreshold
+	switch m.getSelectedSection() {
+	case "completed":
+		nearEnd = nearEnd || (m.completedPage+1)*itemsPerPage >= m.completedTotal
+	case "failed":
+		nearEnd = nearEnd || (m.failedPage+1)*itemsPerPage >= m.failedTotal
+	}
+	if !nearEnd {
+		return nil
+	}
+
+	m.loadingMore = true
+	return m.loadMoreTasks()
+}
+
+// splitTodayRuns sorts runs into running, completed and failed lists
+// (most recent first), skipping runs already listed as needing attention.
+func splitTodayRuns(runs []client.Run, attentionIDs map[string]bool) (running, completed, failed []TaskRun) {
+	for _, r := range runs {
+		if attentionIDs[r.ID] {
+			continue // Already in needs attention section
+		}
+		tr := clientRunToTaskRun(r)
+		if r.Status == "running" {
+			running = append(running, tr)
+		} else if isRunSuccess(r) {
+			completed = append(completed, tr)
+		} else {
+			failed = append(failed, tr)
 		}
 	}
+
+	sortByMostRecent(running)
+	sortByMostRecent(completed)
+	sortByMostRecent(failed)
+	return running, completed, failed
 }
 
 func clientRunToTaskRun(r client.Run) TaskRun {
@@ -295,11 +619,7 @@ func clientRunToTaskRun(r client.Run) TaskRun {
 }
 
 func (m *TasksModal) loadHistory(page int) tea.Cmd {
-	// Get cursor for this page (empty string for page 0)
-	cursor := ""
-	if page > 0 {
-		cursor = m.historyCursors[page]
-	}
+	cursor := m.historyPager.Cursor(page)
 
 	return func() tea.Msg {
 		filter := &client.RunsFilter{
@@ -329,22 +649,14 @@ func (m *TasksModal) loadHistory(page int) tea.Cmd {
 	}
 }
 
-func (m *TasksModal) loadTaskDetail(runID string) tea.Cmd {
+func (m *TasksModal) loadTaskDetail(r TaskRun) tea.Cmd {
+	runID := r.ID
+	retry := client.DefaultRunRetry
+	retry.Finalizing = client.MayBeFinalizing(r.Status, r.EndedAt)
 	return func() tea.Msg {
-		// Retry up to 3 times with a short delay to handle race conditions
-		// where the run just completed but hub-core hasn't finished writing
-		var run *client.Run
-		var err error
-		for attempt := 0; attempt < 3; attempt++ {
-			run, err = m.client.GetRun(runID)
-			if err == nil {
-				break
-			}
-			// If not found, wait a bit and retry (race condition with hub-core)
-			if attempt < 2 {
-				time.Sleep(300 * time.Millisecond)
-			}
-		}
+		// Retry briefly in case the run just completed but hub-core
+		// hasn't finished writing it
+		run, err := m.client.GetRunWithRetry(runID, retry)
 		if err != nil {
 			return TaskDetailLoadedMsg{Error: err}
 		}
@@ -363,30 +675,80 @@ func (m *TasksModal) loadTaskDetail(runID string) tea.Cmd {
 	}
 }
 
+// IsLoading returns true while the list, history, or run details are being fetched.
+func (m *TasksModal) IsLoading() bool {
+	return m.loading || m.loadingDetail || m.loadingMore || m.dismissingAll > 0
+}
+
+// IsCapturingInput returns true while the filter is being typed.
+func (m *TasksModal) IsCapturingInput() bool {
+	return m.filtering
+}
+
 // Update handles input.
 func (m *TasksModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 	switch msg := msg.(type) {
+	case components.SpinnerTickMsg:
+		m.spinner.Advance()
+		return m, nil
+
 	case TasksLoadedMsg:
 		m.loading = false
 		if msg.Error != nil {
 			m.error = msg.Error.Error()
 		} else {
-			m.needsAttention = msg.NeedsAttention
-			m.running = msg.Running
-			m.completed = msg.Completed
-			m.failed = msg.Failed
+			selectedID := m.selectedRunID()
+			m.unfiltered = taskSections{
+				needsAttention: msg.NeedsAttention,
+				running:        msg.Running,
+				completed:      msg.Completed,
+				failed:         msg.Failed,
+			}
 			m.completedPage = 0
 			m.failedPage = 0
-			m.completedTotal = len(msg.Completed)
-			m.failedTotal = len(msg.Failed)
-			m.buildAllRuns()
+			m.applyFilter()
+			m.runsCursor = msg.NextCursor
+			m.runsHasMore = msg.HasMore
+			m.loadingMore = false
+			m.selectRun(selectedID)
+			if m.focusAttention && len(m.needsAttention) > 0 {
+				m.selected = 0
+			}
+			m.focusAttention = false
 			m.error = ""
 		}
 		return m, nil
 
+	case TasksPageLoadedMsg:
+		// Drop pages requested before a reload or filter change
+		if msg.Gen != m.pageGen {
+			return m, nil
+		}
+		m.loadingMore = false
+		if msg.Error != nil {
+			m.error = msg.Error.Error()
+			return m, nil
+		}
+		selectedID := m.selectedRunID()
+		m.unfiltered.running = append(m.unfiltered.running, msg.Running...)
+		m.unfiltered.completed = append(m.unfiltered.completed, msg.Completed...)
+		m.unfiltered.failed = append(m.unfiltered.failed, msg.Failed...)
+		sortByMostRecent(m.unfiltered.running)
+		sortByMostRecent(m.unfiltered.completed)
+		sortByMostRecent(m.unfiltered.failed)
+		m.applyFilter()
+		m.runsCursor = msg.NextCursor
+		m.runsHasMore = msg.HasMore
+		m.selectRun(selectedID)
+		return m, nil
+
 	case TaskDetailLoadedMsg:
 		m.loadingDetail = false
-		if msg.Error != nil {
+		if errors.Is(msg.Error, client.ErrRunNotReady) {
+			m.detailError = "Run is still finalizing. Try again in a moment."
+		} else if client.IsNotFound(msg.Error) {
+			m.detailError = "Run not found; it may have been cleaned up by hub-core."
+		} else if msg.Error != nil {
 			// Show error in detail view, don't hide the whole list
 			m.detailError = msg.Error.Error()
 		} else if msg.Run != nil {
@@ -406,27 +768,45 @@ func (m *TasksModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 		}
 		return m, nil
 
+	case TasksDismissedMsg:
+		return m.handleTasksDismissed(msg)
+
 	case components.ConfirmationExpiredMsg:
 		m.confirm.HandleExpired(msg)
 		return m, nil
 
+	case TasksClockTickMsg:
+		// Receiving the tick redraws the modal; keep ticking while open
+		if msg.Gen != m.clockGen {
+			return m, nil
+		}
+		return m, m.clockTick()
+
+	case components.ClipboardCopiedMsg:
+		if msg.Error != nil {
+			m.detailNotice = "Copy failed: " + msg.Error.Error()
+		} else {
+			m.detailNotice = "Copied to clipboard."
+		}
+		return m, nil
+
+	case RunExportedMsg:
+		if msg.Error != nil {
+			m.detailNotice = "Export failed: " + msg.Error.Error()
+		} else {
+			m.detailNotice = "Exported to " + msg.Path
+		}
+		return m, nil
+
 	case HistoryLoadedMsg:
 		m.loading = false
 		if msg.Error != nil {
 			m.error = msg.Error.Error()
 		} else {
 			m.history = msg.Runs
-			m.historyPage = msg.Page
+			m.historyPager.Loaded(msg.Page, msg.HasMore, msg.NextCursor)
 			m.historyTotal = msg.Total
-			m.historyHasMore = msg.HasMore
 			m.selected = 0
-			// Save cursor for the next page
-			if msg.HasMore && msg.NextCursor != "" {
-				if m.historyCursors == nil {
-					m.historyCursors = make(map[int]string)
-				}
-				m.historyCursors[msg.Page+1] = msg.NextCursor
-			}
 			m.error = ""
 		}
 		return m, nil
@@ -438,38 +818,76 @@ func (m *TasksModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 		if m.view == viewTasksHistory {
 			return m.updateHistory(msg)
 		}
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
 		return m.updateList(msg)
 	}
 	return m, nil
 }
 
+// updateFilter handles typing into the filter.
+func (m *TasksModal) updateFilter(msg tea.KeyMsg) (Modal, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.filtering = false
+		m.setFilter("")
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.filter); len(runes) > 0 {
+			m.setFilter(string(runes[:len(runes)-1]))
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.setFilter(m.filter + string(msg.Runes))
+	case tea.KeyUp:
+		m.selected = moveUp(m.selected, len(m.allRuns))
+	case tea.KeyDown:
+		m.selected = moveDown(m.selected, len(m.allRuns))
+	}
+	return m, nil
+}
+
 func (m *TasksModal) updateList(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch key := msg.String(); {
+	case key == "esc":
 		m.confirm.Clear()
+		if m.filter != "" {
+			// Clear the filter before closing
+			m.setFilter("")
+			return m, nil
+		}
 		return nil, nil // Close modal
-	case "up", "k":
+	case key == "/":
 		m.confirm.Clear()
-		if m.selected > 0 {
-			m.selected--
-		}
-	case "down", "j":
+		m.filtering = true
+	case key == "a":
+		// Toggle relative/absolute times
 		m.confirm.Clear()
-		if m.selected < len(m.allRuns)-1 {
-			m.selected++
-		}
-	case "enter":
+		m.absoluteTimes = !m.absoluteTimes
+		absolute := m.absoluteTimes
+		return m, func() tea.Msg { return TaskTimeFormatChangedMsg{Absolute: absolute} }
+	case m.keys.Is(msg, keys.ListUp):
+		m.confirm.Clear()
+		m.selected = moveUp(m.selected, len(m.allRuns))
+		return m, m.maybeLoadMore()
+	case m.keys.Is(msg, keys.ListDown):
+		m.confirm.Clear()
+		m.selected = moveDown(m.selected, len(m.allRuns))
+		return m, m.maybeLoadMore()
+	case key == "enter":
 		m.confirm.Clear()
 		if len(m.allRuns) > 0 && m.selected < len(m.allRuns) {
 			run := m.allRuns[m.selected]
 			m.detailRun = &run // Show basic info immediately
 			m.previousView = viewTasksList
 			m.view = viewTaskDetail
+			m.detailScroll = 0
 			m.loadingDetail = true
 			// Fetch full details from API
-			return m, m.loadTaskDetail(run.ID)
+			return m, m.loadTaskDetail(run)
 		}
-	case "c":
+	case key == "c":
 		m.confirm.Clear()
 		// Cancel selected running task
 		if len(m.allRuns) > 0 && m.selected < len(m.allRuns) {
@@ -478,7 +896,16 @@ func (m *TasksModal) updateList(msg tea.KeyMsg) (Modal, tea.Cmd) {
 				return m, m.cancelTask(run.ID)
 			}
 		}
-	case "d":
+	case key == "x":
+		m.confirm.Clear()
+		// Re-run the selected run's workflow
+		if len(m.allRuns) > 0 && m.selected < len(m.allRuns) {
+			run := m.allRuns[m.selected]
+			if run.Status != "running" && run.Workflow != "" {
+				return m, rerunTask(run.Workflow)
+			}
+		}
+	case key == "d":
 		// Dismiss selected task that needs attention
 		if len(m.allRuns) > 0 && m.selected < len(m.allRuns) {
 			run := m.allRuns[m.selected]
@@ -490,7 +917,16 @@ func (m *TasksModal) updateList(msg tea.KeyMsg) (Modal, tea.Cmd) {
 				}
 			}
 		}
-	case "n":
+	case key == "D":
+		// Dismiss every run needing attention, not just the filtered ones
+		if len(m.unfiltered.needsAttention) > 0 && m.dismissingAll == 0 {
+			if execute, cmd := m.confirm.Check("dismiss-all", ""); execute {
+				return m, m.dismissAll()
+			} else if cmd != nil {
+				return m, cmd
+			}
+		}
+	case key == "n":
 		// Next page - only for the section where cursor is
 		m.confirm.Clear()
 		section := m.getSelectedSection()
@@ -513,7 +949,8 @@ func (m *TasksModal) updateList(msg tea.KeyMsg) (Modal, tea.Cmd) {
 			// Keep selection at start of the paginated section
 			m.selected = m.getSectionStartIndex(section)
 		}
-	case "p":
+		return m, m.maybeLoadMore()
+	case key == "p":
 		// Previous page - only for the section where cursor is
 		m.confirm.Clear()
 		section := m.getSelectedSection()
@@ -530,28 +967,29 @@ func (m *TasksModal) updateList(msg tea.KeyMsg) (Modal, tea.Cmd) {
 			// Keep selection at start of the paginated section
 			m.selected = m.getSectionStartIndex(section)
 		}
-	case "h":
+	case key == "h":
 		// Switch to history view
 		m.confirm.Clear()
 		m.view = viewTasksHistory
 		m.loading = true
 		m.selected = 0
 		m.history = nil
-		m.historyPage = 0
-		m.historyCursors = make(map[int]string)
+		m.historyPager.Reset()
 		return m, m.loadHistory(0)
-	case "r":
+	case key == "r":
 		// Refresh tasks
 		m.confirm.Clear()
 		m.loading = true
+		m.dismissAllError = ""
 		return m, m.loadTasks()
 	}
 	return m, nil
 }
 
 func (m *TasksModal) updateDetail(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	m.detailNotice = ""
+	switch key := msg.String(); {
+	case key == "esc":
 		// Return to the view we came from (list or history)
 		if m.previousView == viewTasksHistory {
 			m.view = viewTasksHistory
@@ -560,22 +998,65 @@ func (m *TasksModal) updateDetail(msg tea.KeyMsg) (Modal, tea.Cmd) {
 		}
 		m.detailRun = nil
 		m.detailError = ""
+		m.rawOutput = false
+		m.detailScroll = 0
+		m.confirm.Clear()
+	case m.keys.Is(msg, keys.ListUp):
+		m.detailScroll--
+		m.clampDetailScroll(len(m.detailLines()))
+	case m.keys.Is(msg, keys.ListDown):
+		m.detailScroll++
+		m.clampDetailScroll(len(m.detailLines()))
+	case key == "pgup":
+		m.detailScroll -= m.detailHeight
+		m.clampDetailScroll(len(m.detailLines()))
+	case key == "pgdown":
+		m.detailScroll += m.detailHeight
+		m.clampDetailScroll(len(m.detailLines()))
+	case key == "v":
+		// Toggle raw JSON result
+		m.confirm.Clear()
+		m.rawOutput = !m.rawOutput
+		m.detailScroll = 0
+	case key == "y":
+		// Copy the error and output, as shown
+		m.confirm.Clear()
+		if m.detailRun != nil {
+			text := m.copyText()
+			if text == "" {
+				m.detailNotice = "Nothing to copy."
+				return m, nil
+			}
+			return m, components.CopyToClipboard(text)
+		}
+	case key == "e":
+		// Export the full run as JSON
 		m.confirm.Clear()
-	case "r":
+		if m.detailRun != nil {
+			m.detailNotice = "Exporting..."
+			return m, ExportRun(m.client, m.detailRun.ID)
+		}
+	case key == "r":
 		m.confirm.Clear()
 		// Refresh details
 		if m.detailRun != nil && !m.loadingDetail {
 			m.loadingDetail = true
 			m.detailError = ""
-			return m, m.loadTaskDetail(m.detailRun.ID)
+			return m, m.loadTaskDetail(*m.detailRun)
 		}
-	case "c":
+	case key == "c":
 		m.confirm.Clear()
 		// Cancel if running
 		if m.detailRun != nil && m.detailRun.Status == "running" {
 			return m, m.cancelTask(m.detailRun.ID)
 		}
-	case "d":
+	case key == "x":
+		m.confirm.Clear()
+		// Re-run if finished
+		if m.detailRun != nil && m.detailRun.Status != "running" && m.detailRun.Workflow != "" {
+			return m, rerunTask(m.detailRun.Workflow)
+		}
+	case key == "d":
 		// Dismiss if needs attention
 		if m.detailRun != nil && m.detailRun.NeedsAttention {
 			runID := m.detailRun.ID
@@ -597,51 +1078,44 @@ func (m *TasksModal) updateDetail(msg tea.KeyMsg) (Modal, tea.Cmd) {
 }
 
 func (m *TasksModal) updateHistory(msg tea.KeyMsg) (Modal, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch key := msg.String(); {
+	case key == "esc":
 		// Return to main list view
 		m.view = viewTasksList
 		m.selected = 0
 		m.confirm.Clear()
-	case "up", "k":
+	case m.keys.Is(msg, keys.ListUp):
 		m.confirm.Clear()
-		if m.selected > 0 {
-			m.selected--
-		}
-	case "down", "j":
+		m.selected = moveUp(m.selected, len(m.history))
+	case m.keys.Is(msg, keys.ListDown):
 		m.confirm.Clear()
-		if m.selected < len(m.history)-1 {
-			m.selected++
-		}
-	case "enter":
+		m.selected = moveDown(m.selected, len(m.history))
+	case key == "enter":
 		m.confirm.Clear()
 		if len(m.history) > 0 && m.selected < len(m.history) {
 			run := m.history[m.selected]
 			m.detailRun = &run
 			m.previousView = viewTasksHistory
 			m.view = viewTaskDetail
+			m.detailScroll = 0
 			m.loadingDetail = true
-			return m, m.loadTaskDetail(run.ID)
+			return m, m.loadTaskDetail(run)
 		}
-	case "n":
+	case key == "n":
 		// Next page if available
 		m.confirm.Clear()
-		if m.historyHasMore && !m.loading {
-			// Check if we have cursor for next page
-			nextPage := m.historyPage + 1
-			if _, hasCursor := m.historyCursors[nextPage]; hasCursor {
-				m.loading = true
-				return m, m.loadHistory(nextPage)
-			}
+		if m.historyPager.HasNext() && !m.loading {
+			m.loading = true
+			return m, m.loadHistory(m.historyPager.Page() + 1)
 		}
-	case "p":
+	case key == "p":
 		// Previous page
 		m.confirm.Clear()
-		if m.historyPage > 0 && !m.loading {
+		if m.historyPager.HasPrev() && !m.loading {
 			m.loading = true
-			return m, m.loadHistory(m.historyPage - 1)
+			return m, m.loadHistory(m.historyPager.Page() - 1)
 		}
-	case "d":
+	case key == "d":
 		// Dismiss selected task that needs attention
 		if len(m.history) > 0 && m.selected < len(m.history) {
 			run := m.history[m.selected]
@@ -653,11 +1127,11 @@ func (m *TasksModal) updateHistory(msg tea.KeyMsg) (Modal, tea.Cmd) {
 				}
 			}
 		}
-	case "r":
+	case key == "r":
 		// Refresh history
 		m.confirm.Clear()
 		m.loading = true
-		return m, m.loadHistory(m.historyPage)
+		return m, m.loadHistory(m.historyPager.Page())
 	}
 	return m, nil
 }
@@ -673,6 +1147,25 @@ func (m *TasksModal) Title() string {
 	return "Tasks"
 }
 
+// SetKeymap sets the key bindings.
+func (m *TasksModal) SetKeymap(k *keys.Keymap) {
+	m.keys = k
+}
+
+// SetWidth sets the available content width for wrapping.
+func (m *TasksModal) SetWidth(width int) {
+	m.width = width
+}
+
+// SetHeight sizes the detail view to the available content height.
+func (m *TasksModal) SetHeight(height int) {
+	// Reserve the blank line and hint line below the scrolled content
+	m.detailHeight = height - 2
+	if m.detailHeight < minScrollHeight {
+		m.detailHeight = minScrollHeight
+	}
+}
+
 // View renders the modal content.
 func (m *TasksModal) View() string {
 	if m.view == viewTaskDetail {
@@ -686,35 +1179,36 @@ func (m *TasksModal) View() string {
 
 func (m *TasksModal) viewList() string {
 	if m.loading {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("Loading tasks...")
+		return m.spinner.View("Loading tasks...")
 	}
 
 	if m.error != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 		hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
-			errorStyle.Render("Error: "+m.error),
+			renderError("Error: "+m.error, m.width, 0),
 			"",
 			hintStyle.Render("[r] Retry"),
 		)
 	}
 
-	if len(m.allRuns) == 0 {
-		hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-		return lipgloss.JoinVertical(
-			lipgloss.Left,
-			hintStyle.Render("No tasks today."),
-			"",
-			hintStyle.Render("[h] History"),
-		)
+	filterActive := m.filtering || m.filter != ""
+	if len(m.allRuns) == 0 && !filterActive {
+		return emptyState("No tasks today.",
+			"Run a workflow with #name to start one.  [h] History")
 	}
 
 	var lines []string
 	runIndex := 0 // Track index across all sections for selection
 
+	if filterActive {
+		lines = append(lines, m.viewFilter(), "")
+		if len(m.allRuns) == 0 {
+			hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+			lines = append(lines, hintStyle.Render("No matching runs."), "")
+		}
+	}
+
 	headerStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
 	selectedStyle := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
 	normalStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
@@ -742,13 +1236,13 @@ func (m *TasksModal) viewList() string {
 			switch r.Status {
 			case "running":
 				indicator = runningIndicator
-				timeText = "Started " + formatElapsed(r.StartedAt)
+				timeText = "Started " + m.formatRunTime(r.StartedAt)
 			case "completed":
 				indicator = completedIndicator
-				timeText = "Completed " + formatElapsed(r.EndedAt)
+				timeText = "Completed " + m.formatRunTime(r.EndedAt)
 			default:
 				indicator = failedIndicator
-				timeText = "Failed " + formatElapsed(r.EndedAt)
+				timeText = "Failed " + m.formatRunTime(r.EndedAt)
 			}
 			line := fmt.Sprintf("  %s %s    %s", indicator, name, timeStyle.Render(timeText))
 			lines = append(lines, line)
@@ -765,8 +1259,8 @@ func (m *TasksModal) viewList() string {
 			if runIndex == m.selected {
 				name = selectedStyle.Render(r.Workflow)
 			}
-			elapsed := formatElapsed(r.StartedAt)
-			line := fmt.Sprintf("  %s %s    %s", runningIndicator, name, timeStyle.Render("Started "+elapsed))
+			when := m.formatRunTime(r.StartedAt)
+			line := fmt.Sprintf("  %s %s    %s", runningIndicator, name, timeStyle.Render("Started "+when))
 			lines = append(lines, line)
 			runIndex++
 		}
@@ -787,8 +1281,8 @@ func (m *TasksModal) viewList() string {
 			if runIndex == m.selected {
 				name = selectedStyle.Render(r.Workflow)
 			}
-			elapsed := formatElapsed(r.EndedAt)
-			line := fmt.Sprintf("  %s %s    %s", completedIndicator, name, timeStyle.Render("Completed "+elapsed))
+			when := m.formatRunTime(r.EndedAt)
+			line := fmt.Sprintf("  %s %s    %s", completedIndicator, name, timeStyle.Render("Completed "+when))
 			lines = append(lines, line)
 			runIndex++
 		}
@@ -809,12 +1303,12 @@ func (m *TasksModal) viewList() string {
 			if runIndex == m.selected {
 				name = selectedStyle.Render(r.Workflow)
 			}
-			elapsed := formatElapsed(r.EndedAt)
+			when := m.formatRunTime(r.EndedAt)
 			errText := ""
 			if r.Error != "" {
-				errText = "\n      " + lipgloss.NewStyle().Foreground(theme.Error).Render(r.Error)
+				errText = "\n" + renderError(r.Error, m.width, 6)
 			}
-			line := fmt.Sprintf("  %s %s    %s%s", failedIndicator, name, timeStyle.Render("Failed "+elapsed), errText)
+			line := fmt.Sprintf("  %s %s    %s%s", failedIndicator, name, timeStyle.Render("Failed "+when), errText)
 			lines = append(lines, line)
 			runIndex++
 		}
@@ -825,23 +1319,41 @@ func (m *TasksModal) viewList() string {
 	hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 	warningHintStyle := lipgloss.NewStyle().Foreground(theme.Warning)
 
-	// Check if selected task needs attention for dismiss hint
-	var selectedNeedsAttention bool
+	// Check if selected task needs attention for dismiss hint, or has
+	// finished for the re-run hint
+	var selectedNeedsAttention, selectedFinished bool
 	if len(m.allRuns) > 0 && m.selected < len(m.allRuns) {
-		selectedNeedsAttention = m.allRuns[m.selected].NeedsAttention
+		run := m.allRuns[m.selected]
+		selectedNeedsAttention = run.NeedsAttention
+		selectedFinished = run.Status != "running" && run.Workflow != ""
 	}
 
 	// Check for pending dismiss confirmation
-	if m.confirm.IsPending("dismiss", "") {
+	if m.dismissAllError != "" {
+		lines = append(lines, renderError(m.dismissAllError, m.width, 0), "")
+	}
+	if m.dismissingAll > 0 {
+		lines = append(lines, m.spinner.View("Dismissing runs needing attention..."))
+	} else if m.filtering {
+		lines = append(lines, hintStyle.Render("[Enter] Done  [Esc] Clear filter"))
+	} else if m.confirm.IsPending("dismiss-all", "") {
+		lines = append(lines, warningHintStyle.Render(fmt.Sprintf("Press D again to dismiss all %d needing attention", len(m.unfiltered.needsAttention))))
+	} else if m.confirm.IsPending("dismiss", "") {
 		lines = append(lines, warningHintStyle.Render("Press d again to dismiss"))
 	} else {
-		hints := "[Enter] Details  [r] Refresh"
+		hints := "[Enter] Details  [r] Refresh  [/] Filter"
 		if len(m.running) > 0 {
 			hints += "  [c] Cancel"
 		}
+		if selectedFinished {
+			hints += "  [x] Re-run"
+		}
 		if selectedNeedsAttention {
 			hints += "  [d] Dismiss"
 		}
+		if len(m.unfiltered.needsAttention) > 1 {
+			hints += "  [D] Dismiss all"
+		}
 		// Add pagination hints only if current section has multiple pages
 		section := m.getSelectedSection()
 		showPagination := false
@@ -853,26 +1365,49 @@ func (m *TasksModal) viewList() string {
 		if showPagination {
 			hints += "  [n/p] Next/Prev page"
 		}
+		if m.absoluteTimes {
+			hints += "  [a] Relative times"
+		} else {
+			hints += "  [a] Exact times"
+		}
 		hints += "  [h] History"
+		if m.loadingMore {
+			lines = append(lines, m.spinner.View("Loading more runs..."))
+		}
 		lines = append(lines, hintStyle.Render(hints))
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// viewFilter renders the filter text and match count.
+func (m *TasksModal) viewFilter() string {
+	labelStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	textStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
+
+	text := m.filter
+	if m.filtering {
+		text += "▏"
+	}
+	matches := len(m.needsAttention) + len(m.running) + len(m.completed) + len(m.failed)
+	noun := "matches"
+	if matches == 1 {
+		noun = "match"
+	}
+	return labelStyle.Render("Filter: ") + textStyle.Render(text) +
+		labelStyle.Render(fmt.Sprintf("  (%d %s)", matches, noun))
+}
+
 func (m *TasksModal) viewHistory() string {
 	if m.loading {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("Loading history...")
+		return m.spinner.View("Loading history...")
 	}
 
 	if m.error != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 		hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
-			errorStyle.Render("Error: "+m.error),
+			renderError("Error: "+m.error, m.width, 0),
 			"",
 			hintStyle.Render("[Esc] Back  [r] Retry"),
 		)
@@ -901,7 +1436,7 @@ func (m *TasksModal) viewHistory() string {
 	attentionIndicator := lipgloss.NewStyle().Foreground(theme.Warning).Bold(true).Render("⚠")
 
 	// Show range and total count
-	startItem := m.historyPage*historyItemsPerPage + 1
+	startItem := m.historyPager.Page()*historyItemsPerPage + 1
 	endItem := startItem + len(m.history) - 1
 	countText := fmt.Sprintf("Showing %d-%d of %d tasks", startItem, endItem, m.historyTotal)
 	lines = append(lines, pageStyle.Render(countText))
@@ -961,9 +1496,7 @@ func (m *TasksModal) viewHistory() string {
 			hints += "  [d] Dismiss"
 		}
 		// Show pagination hints if there are multiple pages
-		hasNextPage := m.historyHasMore
-		hasPrevPage := m.historyPage > 0
-		if hasNextPage || hasPrevPage {
+		if m.historyPager.HasNext() || m.historyPager.HasPrev() {
 			hints += "  [n/p] Next/Prev page"
 		}
 		lines = append(lines, hintStyle.Render(hints))
@@ -977,6 +1510,59 @@ func (m *TasksModal) viewDetail() string {
 		return "No task selected"
 	}
 
+	r := m.detailRun
+	lines := m.detailLines()
+
+	// Apply scrolling
+	scroll := m.clampDetailScroll(len(lines))
+	end := scroll + m.detailHeight
+	if end > len(lines) {
+		end = len(lines)
+	}
+	visible := append([]string{}, lines[scroll:end]...)
+
+	visible = append(visible, "")
+	hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	warningHintStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+
+	// Check for pending dismiss confirmation
+	if m.confirm.IsPending("dismiss", r.ID) {
+		visible = append(visible, warningHintStyle.Render("Press d again to dismiss"))
+	} else if m.detailNotice != "" {
+		visible = append(visible, wrapText(hintStyle, m.detailNotice, m.width, 0))
+	} else {
+		hints := "[Esc] Back  [r] Refresh"
+		if m.rawOutput {
+			hints += "  [v] Formatted"
+		} else {
+			hints += "  [v] Raw"
+		}
+		if r.Error != "" || formatRunOutput(r.Result) != "" {
+			hints += "  [y] Copy"
+		}
+		hints += "  [e] Export"
+		if r.Status == "running" {
+			hints += "  [c] Cancel"
+		} else if r.Workflow != "" {
+			hints += "  [x] Re-run"
+		}
+		if r.NeedsAttention {
+			hints += "  [d] Dismiss"
+		}
+		if len(lines) > m.detailHeight {
+			hints += fmt.Sprintf("  [↑/↓] Scroll %d-%d of %d", scroll+1, end, len(lines))
+		}
+		visible = append(visible, hintStyle.Render(hints))
+	}
+
+	return strings.Join(visible, "\n")
+}
+
+// detailLines builds the scrollable body of the detail view, one entry per screen line.
+func (m *TasksModal) detailLines() []string {
+	if m.detailRun == nil {
+		return nil
+	}
 	r := m.detailRun
 	labelStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 	valueStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
@@ -1004,12 +1590,10 @@ func (m *TasksModal) viewDetail() string {
 	// Show loading indicator or error for fetching full details
 	if m.loadingDetail {
 		lines = append(lines, "")
-		lines = append(lines, labelStyle.Render("Loading details..."))
+		lines = append(lines, m.spinner.View("Loading details..."))
 	} else if m.detailError != "" {
 		lines = append(lines, "")
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
-		lines = append(lines, errorStyle.Render("Could not load full details: "+m.detailError))
-		lines = append(lines, labelStyle.Render("(Run may have been cleaned up by hub-core)"))
+		lines = append(lines, renderError("Could not load full details: "+m.detailError, m.width, 0))
 	}
 
 	if !r.EndedAt.IsZero() {
@@ -1021,38 +1605,112 @@ func (m *TasksModal) viewDetail() string {
 	if r.Error != "" {
 		lines = append(lines, "")
 		lines = append(lines, labelStyle.Render("Error:"))
-		lines = append(lines, lipgloss.NewStyle().Foreground(theme.Error).Render("  "+r.Error))
+		lines = append(lines, renderError(r.Error, m.width, 2))
 	}
 
-	output := formatRunOutput(r.Result)
-	if output != "" {
+	if m.rawOutput {
 		lines = append(lines, "")
-		lines = append(lines, labelStyle.Render("Output:"))
-		// Indent output lines
-		for _, line := range strings.Split(output, "\n") {
+		lines = append(lines, labelStyle.Render("Result (raw):"))
+		for _, line := range strings.Split(formatRawResult(r.Result), "\n") {
 			lines = append(lines, "  "+valueStyle.Render(line))
 		}
+	} else {
+		if r.Result != nil && len(r.Result.Steps) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, labelStyle.Render("Steps:"))
+			lines = append(lines, m.viewSteps(r.Result.Steps)...)
+		}
+
+		output := formatRunOutput(r.Result)
+		if output != "" {
+			lines = append(lines, "")
+			lines = append(lines, labelStyle.Render("Output:"))
+			// Indent output lines
+			for _, line := range strings.Split(output, "\n") {
+				lines = append(lines, "  "+valueStyle.Render(line))
+			}
+		}
 	}
 
-	lines = append(lines, "")
-	hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	warningHintStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	// Wrapped entries span several lines; split so scrolling counts screen lines
+	return strings.Split(strings.Join(lines, "\n"), "\n")
+}
 
-	// Check for pending dismiss confirmation
-	if m.confirm.IsPending("dismiss", r.ID) {
-		lines = append(lines, warningHintStyle.Render("Press d again to dismiss"))
-	} else {
-		hints := "[Esc] Back  [r] Refresh"
-		if r.Status == "running" {
-			hints += "  [c] Cancel"
+// copyText returns the detail run's error and output for the clipboard,
+// with the output raw or formatted as currently shown. A run with both
+// gets a labelled block of each.
+func (m *TasksModal) copyText() string {
+	r := m.detailRun
+	output := formatRunOutput(r.Result)
+	if m.rawOutput && r.Result != nil {
+		output = formatRawResult(r.Result)
+	}
+
+	switch {
+	case r.Error != "" && output != "":
+		return "Error:\n" + r.Error + "\n\nOutput:\n" + output
+	case r.Error != "":
+		return r.Error
+	default:
+		return output
+	}
+}
+
+// clampDetailScroll keeps the detail scroll offset within the content bounds.
+func (m *TasksModal) clampDetailScroll(total int) int {
+	maxScroll := total - m.detailHeight
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.detailScroll > maxScroll {
+		m.detailScroll = maxScroll
+	}
+	if m.detailScroll < 0 {
+		m.detailScroll = 0
+	}
+	return m.detailScroll
+}
+
+// viewSteps renders one line per workflow step with its status and duration.
+// Steps without timing information are listed without a duration.
+func (m *TasksModal) viewSteps(steps []client.StepResult) []string {
+	nameStyle := lipgloss.NewStyle().Foreground(theme.TextPrimary)
+	timeStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
+	successIndicator := lipgloss.NewStyle().Foreground(theme.Success).Render("✓")
+	failedIndicator := lipgloss.NewStyle().Foreground(theme.Error).Render("✗")
+
+	// Align durations in a column after the longest step name
+	nameWidth := 0
+	for _, s := range steps {
+		if w := lipgloss.Width(s.StepName); w > nameWidth {
+			nameWidth = w
 		}
-		if r.NeedsAttention {
-			hints += "  [d] Dismiss"
+	}
+
+	var lines []string
+	for _, s := range steps {
+		indicator := successIndicator
+		if !s.Success {
+			indicator = failedIndicator
+		}
+		name := s.StepName + strings.Repeat(" ", nameWidth-lipgloss.Width(s.StepName))
+		line := "  " + indicator + " " + nameStyle.Render(name)
+		if s.StartedAt != nil && s.EndedAt != nil {
+			line += "  " + timeStyle.Render(formatDuration(s.EndedAt.Sub(*s.StartedAt)))
+		}
+		lines = append(lines, line)
+		if s.Error != "" {
+			lines = append(lines, renderError(s.Error, m.width, 6))
 		}
-		lines = append(lines, hintStyle.Render(hints))
 	}
+	return lines
+}
 
-	return strings.Join(lines, "\n")
+// rerunTask returns a command asking the app to run workflow again.
+func rerunTask(workflow string) tea.Cmd {
+	return func() tea.Msg {
+		return TaskRerunRequestMsg{Workflow: workflow}
+	}
 }
 
 // cancelTask returns a command to reload tasks after cancelling.
@@ -1129,6 +1787,78 @@ func (m *TasksModal) dismissTask(runID string) tea.Cmd {
 	}
 }
 
+// dismissAll returns a command that dismisses every run needing attention.
+// Runs are dismissed concurrently and reported together in one message, so
+// the list is refreshed once at the end.
+func (m *TasksModal) dismissAll() tea.Cmd {
+	ids := make([]string, len(m.unfiltered.needsAttention))
+	for i, run := range m.unfiltered.needsAttention {
+		ids[i] = run.ID
+	}
+	m.dismissingAll = len(ids)
+	m.dismissAllError = ""
+
+	return func() tea.Msg {
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			authErr error
+		)
+		failed := make(map[string]error)
+		jobs := make(chan string)
+
+		for w := 0; w < min(dismissWorkers, len(ids)); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for id := range jobs {
+					err := m.client.DismissRun(id)
+					if err == nil {
+						continue
+					}
+					mu.Lock()
+					failed[id] = err
+					if client.IsAuthError(err) {
+						authErr = err
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+
+		for _, id := range ids {
+			jobs <- id
+		}
+		close(jobs)
+		wg.Wait()
+
+		return TasksDismissedMsg{Dismissed: len(ids) - len(failed), Failed: failed, Error: authErr}
+	}
+}
+
+// handleTasksDismissed records any failures from a dismiss-all and reloads
+// the list.
+func (m *TasksModal) handleTasksDismissed(msg TasksDismissedMsg) (Modal, tea.Cmd) {
+	total := m.dismissingAll
+	m.dismissingAll = 0
+	m.confirm.Clear()
+
+	if len(msg.Failed) > 0 {
+		// Report one error; the rest are usually the same
+		ids := make([]string, 0, len(msg.Failed))
+		for id := range msg.Failed {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		m.dismissAllError = fmt.Sprintf("Dismissed %d of %d; %d failed: %v",
+			msg.Dismissed, total, len(msg.Failed), msg.Failed[ids[0]])
+	}
+	if msg.Dismissed == 0 {
+		return m, nil
+	}
+	return m, m.loadTasks()
+}
+
 // sortByMostRecent sorts tasks with needs_attention first, then by most recent.
 func sortByMostRecent(tasks []TaskRun) {
 	sort.Slice(tasks, func(i, j int) bool {
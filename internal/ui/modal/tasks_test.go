@@ -0,0 +1,34 @@
+package modal
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pxp/hub-tui/internal/client"
+)
+
+func TestTasksFilterTakesQ(t *testing.T) {
+	var s State
+	tm := NewTasksModal(client.NewMock(), time.Second)
+	tm = runCmd(t, tm, tm.Init()).(*TasksModal)
+	s.Open(tm)
+
+	for _, k := range []string{"/", "q"} {
+		s.Update(keyMsg(k))
+	}
+	if s.Active == nil {
+		t.Fatal("q closed the modal while typing the filter")
+	}
+	if tm.filter != "q" {
+		t.Errorf("filter = %q, want q", tm.filter)
+	}
+
+	// Once the filter is applied, q closes the modal again
+	s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	s.Update(keyMsg("q"))
+	if s.Active != nil {
+		t.Error("q didn't close the modal outside the filter")
+	}
+}
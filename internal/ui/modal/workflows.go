@@ -9,23 +9,35 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/pxp/hub-tui/internal/client"
+	"github.com/pxp/hub-tui/internal/ui/keys"
 	"github.com/pxp/hub-tui/internal/ui/theme"
 )
 
+// workflowsClient is the part of the hub-core API used by WorkflowsModal.
+type workflowsClient interface {
+	ListWorkflows() ([]client.Workflow, error)
+}
+
 // WorkflowsModal displays and manages workflows.
 type WorkflowsModal struct {
-	client    *client.Client
-	workflows []client.Workflow
-	selected  int
-	loading   bool
-	error     string
+	client      workflowsClient
+	workflows   []client.Workflow
+	visible     []client.Workflow // workflows after the enabled filter; selected indexes this
+	enabledOnly bool              // Hide disabled workflows
+	selected    int
+	loading     bool
+	error       string
+	width       int
+	rows        listHitMap // Content line of each workflow, for mouse clicks
+	keys        *keys.Keymap
 }
 
 // NewWorkflowsModal creates a new workflows modal.
-func NewWorkflowsModal(c *client.Client) *WorkflowsModal {
+func NewWorkflowsModal(c workflowsClient) *WorkflowsModal {
 	return &WorkflowsModal{
 		client:  c,
 		loading: true,
+		keys:    keys.Default(),
 	}
 }
 
@@ -35,12 +47,48 @@ type WorkflowsLoadedMsg struct {
 	Error     error
 }
 
+// WorkflowFilterChangedMsg is sent when the enabled-only filter is toggled,
+// so the preference can be saved.
+type WorkflowFilterChangedMsg struct {
+	EnabledOnly bool
+}
+
 // WorkflowRunMsg is sent when a workflow run is initiated.
 type WorkflowRunMsg struct {
 	Name  string
 	Error error
 }
 
+// SetEnabledOnly sets whether disabled workflows are hidden.
+func (m *WorkflowsModal) SetEnabledOnly(enabledOnly bool) {
+	m.enabledOnly = enabledOnly
+	m.applyFilter()
+}
+
+// applyFilter rebuilds the visible list, keeping the selected workflow
+// selected if it's still shown.
+func (m *WorkflowsModal) applyFilter() {
+	selectedName := ""
+	if m.selected >= 0 && m.selected < len(m.visible) {
+		selectedName = m.visible[m.selected].Name
+	}
+
+	m.visible = m.visible[:0]
+	for _, wf := range m.workflows {
+		if wf.Enabled || !m.enabledOnly {
+			m.visible = append(m.visible, wf)
+		}
+	}
+
+	m.selected = 0
+	for i, wf := range m.visible {
+		if wf.Name == selectedName {
+			m.selected = i
+			break
+		}
+	}
+}
+
 // Init initializes the modal and triggers data fetch.
 func (m *WorkflowsModal) Init() tea.Cmd {
 	return m.loadWorkflows()
@@ -63,22 +111,33 @@ func (m *WorkflowsModal) Update(msg tea.Msg) (Modal, tea.Cmd) {
 		} else {
 			m.workflows = msg.Workflows
 			m.error = ""
+			m.applyFilter()
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		// Workflows have no Enter action, so clicks only select
+		if line, ok := leftClickLine(msg); ok && !m.loading {
+			if i, ok := m.rows.itemAt(line); ok {
+				m.selected = i
+			}
 		}
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case msg.String() == "esc":
 			return nil, nil // Close modal
-		case "up", "k":
-			if m.selected > 0 {
-				m.selected--
-			}
-		case "down", "j":
-			if m.selected < len(m.workflows)-1 {
-				m.selected++
-			}
-		case "r":
+		case m.keys.Is(msg, keys.ListUp):
+			m.selected = moveUp(m.selected, len(m.visible))
+		case m.keys.Is(msg, keys.ListDown):
+			m.selected = moveDown(m.selected, len(m.visible))
+		case msg.String() == "e":
+			// Toggle hiding disabled workflows
+			m.SetEnabledOnly(!m.enabledOnly)
+			enabledOnly := m.enabledOnly
+			return m, func() tea.Msg { return WorkflowFilterChangedMsg{EnabledOnly: enabledOnly} }
+		case msg.String() == "r":
 			m.loading = true
 			m.error = ""
 			return m, m.loadWorkflows()
@@ -92,8 +151,19 @@ func (m *WorkflowsModal) Title() string {
 	return "Workflows"
 }
 
+// SetKeymap sets the key bindings.
+func (m *WorkflowsModal) SetKeymap(k *keys.Keymap) {
+	m.keys = k
+}
+
+// SetWidth sets the available content width for wrapping.
+func (m *WorkflowsModal) SetWidth(width int) {
+	m.width = width
+}
+
 // View renders the modal content.
 func (m *WorkflowsModal) View() string {
+	m.rows.reset()
 	if m.loading {
 		return lipgloss.NewStyle().
 			Foreground(theme.TextSecondary).
@@ -101,20 +171,22 @@ func (m *WorkflowsModal) View() string {
 	}
 
 	if m.error != "" {
-		errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
 		hintStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
-			errorStyle.Render("Error: "+m.error),
+			renderError("Error: "+m.error, m.width, 0),
 			"",
 			hintStyle.Render("[r] Retry"),
 		)
 	}
 
 	if len(m.workflows) == 0 {
-		return lipgloss.NewStyle().
-			Foreground(theme.TextSecondary).
-			Render("No workflows found.")
+		return emptyState("No workflows found.",
+			"Workflows are defined in hub-core. [r] Refresh, or run /refresh after adding one.")
+	}
+	if len(m.visible) == 0 {
+		return emptyState("No enabled workflows.",
+			"Disabled workflows are hidden. [e] Show all")
 	}
 
 	var lines []string
@@ -127,7 +199,7 @@ func (m *WorkflowsModal) View() string {
 
 	// Calculate max name length for alignment
 	maxNameLen := 0
-	for _, wf := range m.workflows {
+	for _, wf := range m.visible {
 		if len(wf.Name) > maxNameLen {
 			maxNameLen = len(wf.Name)
 		}
@@ -136,7 +208,7 @@ func (m *WorkflowsModal) View() string {
 		maxNameLen = 15
 	}
 
-	for i, wf := range m.workflows {
+	for i, wf := range m.visible {
 		// Status indicator
 		var indicator string
 		if wf.Enabled {
@@ -192,15 +264,27 @@ func (m *WorkflowsModal) View() string {
 			dimStyle.Render(nextRunInfo),
 		)
 
+		m.rows.set(len(lines), i)
 		lines = append(lines, line)
 	}
 
 	// Add legend and hints
 	lines = append(lines, "")
 	legendStyle := lipgloss.NewStyle().Foreground(theme.TextSecondary)
-	lines = append(lines, legendStyle.Render("  ● enabled  ○ disabled"))
+	if m.enabledOnly {
+		hidden := len(m.workflows) - len(m.visible)
+		lines = append(lines, legendStyle.Render(fmt.Sprintf("  ● enabled  (%d disabled hidden)", hidden)))
+	} else {
+		lines = append(lines, legendStyle.Render("  ● enabled  ○ disabled"))
+	}
 	lines = append(lines, "")
-	lines = append(lines, legendStyle.Render("  Use #workflow to run  [r] Refresh"))
+	hints := "  Use #workflow to run  [r] Refresh"
+	if m.enabledOnly {
+		hints += "  [e] Show all"
+	} else {
+		hints += "  [e] Enabled only"
+	}
+	lines = append(lines, legendStyle.Render(hints))
 
 	return strings.Join(lines, "\n")
 }
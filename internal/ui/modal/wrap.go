@@ -0,0 +1,23 @@
+package modal
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pxp/hub-tui/internal/ui/theme"
+)
+
+// wrapText word-wraps text to width, indenting every line by indent columns.
+// Existing newlines are preserved. A non-positive width disables wrapping.
+func wrapText(style lipgloss.Style, text string, width, indent int) string {
+	style = style.PaddingLeft(indent)
+	if width > indent {
+		style = style.Width(width)
+	}
+	return style.Render(text)
+}
+
+// renderError renders an error message in the theme's error color,
+// wrapped to the modal content width.
+func renderError(text string, width, indent int) string {
+	return wrapText(lipgloss.NewStyle().Foreground(theme.Error), text, width, indent)
+}
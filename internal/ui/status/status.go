@@ -3,6 +3,8 @@ package status
 import (
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -28,6 +30,15 @@ type Model struct {
 	contextName        string // Name of assistant/workflow
 	runningCount       int    // Number of running tasks
 	needsAttentionCount int   // Number of tasks needing attention
+	activeProfile      string // Default LLM profile name
+	frame              int    // Animation frame for the connecting indicator
+	quitHint           string // Replaces the default hint after the first Ctrl+C
+
+	// Label of the quit key ("" for Ctrl+C)
+	quitKey string
+
+	// When the cache was last refreshed, zero before the first refresh
+	lastRefresh time.Time
 }
 
 // New creates a new status bar model.
@@ -39,7 +50,7 @@ func New() Model {
 
 // SetWidth sets the status bar width.
 func (m *Model) SetWidth(width int) {
-	m.width = width
+	m.width = max(width, 0)
 }
 
 // SetState sets the connection state.
@@ -47,6 +58,16 @@ func (m *Model) SetState(state State) {
 	m.state = state
 }
 
+// State returns the connection state.
+func (m Model) State() State {
+	return m.state
+}
+
+// SetFrame sets the animation frame of the connecting indicator.
+func (m *Model) SetFrame(frame int) {
+	m.frame = frame
+}
+
 // SetServerURL sets the server URL to display.
 func (m *Model) SetServerURL(serverURL string) {
 	m.serverURL = serverURL
@@ -57,12 +78,33 @@ func (m *Model) SetCtrlCPressed(pressed bool) {
 	m.ctrlCPressed = pressed
 }
 
+// SetQuitKey sets the quit key label shown in the hints, e.g. "Ctrl+Q".
+func (m *Model) SetQuitKey(label string) {
+	m.quitKey = label
+}
+
+// SetQuitHint sets the hint shown after the first Ctrl+C.
+// An empty hint restores the default.
+func (m *Model) SetQuitHint(hint string) {
+	m.quitHint = hint
+}
+
 // SetContext sets the current conversation context.
 func (m *Model) SetContext(contextType, contextName string) {
 	m.contextType = contextType
 	m.contextName = contextName
 }
 
+// SetActiveProfile sets the default LLM profile name to display.
+func (m *Model) SetActiveProfile(name string) {
+	m.activeProfile = name
+}
+
+// SetLastRefresh sets when the cache was last refreshed, shown as its age.
+func (m *Model) SetLastRefresh(t time.Time) {
+	m.lastRefresh = t
+}
+
 // SetTaskCounts sets the running and needs-attention task counts.
 func (m *Model) SetTaskCounts(running, needsAttention int) {
 	m.runningCount = running
@@ -82,7 +124,9 @@ func (m Model) View() string {
 			Foreground(theme.Success)
 
 	case StateConnecting:
-		statusText = "Connecting..."
+		// Animated ellipsis, padded so the bar doesn't shift
+		dots := m.frame % 4
+		statusText = "Connecting" + strings.Repeat(".", dots) + strings.Repeat(" ", 3-dots)
 		statusStyle = lipgloss.NewStyle().
 			Foreground(theme.Warning)
 
@@ -102,19 +146,48 @@ func (m Model) View() string {
 		leftContent += "  " + contextStyle.Render("@"+m.contextName)
 	}
 
+	// Add default LLM profile indicator
+	if m.activeProfile != "" {
+		profileStyle := lipgloss.NewStyle().
+			Foreground(theme.TextSecondary)
+		leftContent += "  " + profileStyle.Render("★ "+m.activeProfile)
+	}
+
 	// Build task indicator
 	taskIndicator := m.taskIndicator()
 
 	// Right side hint
+	quitKey := m.quitKey
+	if quitKey == "" {
+		quitKey = "Ctrl+C"
+	}
 	var rightContent string
 	if m.ctrlCPressed {
+		hint := m.quitHint
+		if hint == "" {
+			hint = "Press " + quitKey + " again to quit"
+		}
 		rightContent = lipgloss.NewStyle().
 			Foreground(theme.Warning).
-			Render("Press Ctrl+C again to quit")
+			Render(hint)
 	} else {
 		rightContent = lipgloss.NewStyle().
 			Foreground(theme.TextSecondary).
-			Render("Ctrl+C to quit")
+			Render(quitKey + " to quit")
+	}
+
+	// Show the cache's age before the hint if there's room for it
+	if !m.lastRefresh.IsZero() && !m.ctrlCPressed {
+		refreshStyle := lipgloss.NewStyle().
+			Foreground(theme.TextSecondary)
+		refreshed := refreshStyle.Render("updated "+formatAge(time.Since(m.lastRefresh))) + "  "
+		used := lipgloss.Width(leftContent) + lipgloss.Width(taskIndicator) + lipgloss.Width(rightContent) + 3
+		if taskIndicator != "" {
+			used += 2
+		}
+		if used+lipgloss.Width(refreshed) <= m.width {
+			rightContent = refreshed + rightContent
+		}
 	}
 
 	// Calculate content widths
@@ -147,6 +220,20 @@ func (m Model) View() string {
 	return barStyle.Render(leftContent + repeatSpace(padding) + rightContent)
 }
 
+// formatAge formats how long ago something happened, e.g. "2m ago".
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
 func repeatSpace(n int) string {
 	if n <= 0 {
 		return ""
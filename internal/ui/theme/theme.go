@@ -2,6 +2,16 @@ package theme
 
 import "github.com/charmbracelet/lipgloss"
 
+// Dark reports whether the terminal has a dark background. Set at startup
+// with DetectBackground; the default code highlighting theme follows it.
+var Dark = true
+
+// DetectBackground sets Dark by querying the terminal. Call it before the
+// program starts, while the terminal can still answer.
+func DetectBackground() {
+	Dark = lipgloss.HasDarkBackground()
+}
+
 // Colors - dark theme using grays (not pure black)
 var (
 	Background    = lipgloss.Color("#1a1a1a") // Dark gray
@@ -1,5 +1,6 @@
 #!/bin/bash
 set -e
 cd "$(dirname "$0")/.."
-go build -o bin/hub-tui ./cmd/hub-tui
+VERSION="$(git describe --tags --always --dirty 2>/dev/null || echo dev)"
+go build -ldflags "-X github.com/pxp/hub-tui/internal/version.Version=$VERSION" -o bin/hub-tui ./cmd/hub-tui
 echo "Built: bin/hub-tui"